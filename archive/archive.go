@@ -0,0 +1,193 @@
+// Package archive store trending snapshots in a local bbolt file, so past trending lists can be queried
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+	bolt "go.etcd.io/bbolt"
+)
+
+// timeKeyLayout fixed width, so keys are sorted by time
+const timeKeyLayout = "2006-01-02T15:04:05.000000000Z"
+
+var snapshotsBucket = []byte("snapshots")
+
+// Snapshot trending repositories fetched at a time
+type Snapshot struct {
+	Lang       string                 `json:"lang"`        // empty means any language
+	SpokenLang string                 `json:"spoken_lang"` // empty means any spoken language
+	Since      string                 `json:"since"`       // daily/weekly/monthly
+	Time       time.Time              `json:"time"`
+	Repos      []*ghsearch.Repository `json:"repos"` // rank is index+1
+}
+
+// Rank rank of repository author/name in snapshot, start from 1. 0 if not in it
+func (s *Snapshot) Rank(author, name string) int {
+	for i, r := range s.Repos {
+		if r.Author == author && r.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// series key of snapshots of the same list
+func (s *Snapshot) series() []byte {
+	return []byte(strings.Join([]string{normLang(s.Lang), normLower(s.SpokenLang), normLower(s.Since)}, "\x00"))
+}
+
+// normLang language slug, so Go and go, c# and c%23 are the same list
+func normLang(lang string) string {
+	if slug, err := ghsearch.LanguageSlug(lang); err == nil {
+		return slug
+	}
+	return normLower(lang)
+}
+
+func normLower(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Capture fetch trending repositories and make a snapshot of now.
+// snapshot is still returned with *ghsearch.ParseError if some fields are missing
+func Capture(ctx context.Context, client *ghsearch.Client, lang, spokenLang, since string) (*Snapshot, error) {
+	repos, err := client.TrendingReposContext(ctx, lang, since, spokenLang)
+	var perr *ghsearch.ParseError
+	if err != nil && !errors.As(err, &perr) {
+		return nil, err
+	}
+	return &Snapshot{Lang: normLang(lang), SpokenLang: normLower(spokenLang), Since: normLower(since), Time: time.Now(), Repos: repos}, err
+}
+
+// Filter select snapshots. empty fields match any
+type Filter struct {
+	Lang       string
+	SpokenLang string
+	Since      string
+	From       time.Time // inclusive
+	To         time.Time // exclusive
+}
+
+// matchSeries series key matches f
+func (f *Filter) matchSeries(series []byte) bool {
+	parts := strings.Split(string(series), "\x00")
+	if len(parts) != 3 {
+		return false
+	}
+	return (len(f.Lang) == 0 || normLang(f.Lang) == normLang(parts[0])) &&
+		(len(f.SpokenLang) == 0 || normLower(f.SpokenLang) == parts[1]) &&
+		(len(f.Since) == 0 || normLower(f.Since) == parts[2])
+}
+
+// Store snapshots in a bbolt file
+type Store struct {
+	db *bolt.DB
+}
+
+// Open open or create store file
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("archive: open %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(snapshotsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close close store file
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save save snapshot. snapshot of the same list and time is replaced
+func (s *Store) Save(snap *Snapshot) error {
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		series, err := tx.Bucket(snapshotsBucket).CreateBucketIfNotExists(snap.series())
+		if err != nil {
+			return err
+		}
+		return series.Put([]byte(snap.Time.UTC().Format(timeKeyLayout)), b)
+	})
+}
+
+// Query snapshots matching f, sorted by list then time
+func (s *Store) Query(f Filter) ([]*Snapshot, error) {
+	var (
+		snaps []*Snapshot
+		from  []byte
+		to    []byte
+	)
+	if !f.From.IsZero() {
+		from = []byte(f.From.UTC().Format(timeKeyLayout))
+	}
+	if !f.To.IsZero() {
+		to = []byte(f.To.UTC().Format(timeKeyLayout))
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(snapshotsBucket)
+		return root.ForEach(func(name, _ []byte) error {
+			if !f.matchSeries(name) {
+				return nil
+			}
+			c := root.Bucket(name).Cursor()
+			k, v := c.First()
+			if from != nil {
+				k, v = c.Seek(from)
+			}
+			for ; k != nil && (to == nil || string(k) < string(to)); k, v = c.Next() {
+				snap := &Snapshot{}
+				if err := json.Unmarshal(v, snap); err != nil {
+					return fmt.Errorf("archive: snapshot %s: %w", k, err)
+				}
+				snaps = append(snaps, snap)
+			}
+			return nil
+		})
+	})
+	return snaps, err
+}
+
+// Previous latest snapshot of the list saved before t. nil if there is none
+func (s *Store) Previous(lang, spokenLang, since string, before time.Time) (*Snapshot, error) {
+	var snap *Snapshot
+	series := (&Snapshot{Lang: lang, SpokenLang: spokenLang, Since: since}).series()
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(snapshotsBucket).Bucket(series)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		k, v := c.Seek([]byte(before.UTC().Format(timeKeyLayout)))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		if k == nil {
+			return nil
+		}
+		snap = &Snapshot{}
+		if err := json.Unmarshal(v, snap); err != nil {
+			return fmt.Errorf("archive: snapshot %s: %w", k, err)
+		}
+		return nil
+	})
+	return snap, err
+}
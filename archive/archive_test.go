@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+)
+
+func TestStore(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "trending.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	repos := []*ghsearch.Repository{{Author: "golang", Name: "go", Stars: 100}, {Author: "ollama", Name: "ollama", Stars: 50}}
+	snaps := []*Snapshot{
+		{Lang: "go", Since: "daily", Time: day, Repos: repos},
+		{Lang: "go", Since: "daily", Time: day.Add(24 * time.Hour), Repos: repos[1:]},
+		{Lang: "go", Since: "weekly", Time: day, Repos: repos},
+		{Lang: "rust", Since: "daily", Time: day, Repos: repos},
+	}
+	for _, snap := range snaps {
+		if err := s.Save(snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		f    Filter
+		want int
+	}{
+		{Filter{}, 4},
+		{Filter{Lang: "Go"}, 3},
+		{Filter{Lang: "go", Since: "daily"}, 2},
+		{Filter{From: day, To: day.Add(24 * time.Hour)}, 3},
+		{Filter{Lang: "go", Since: "daily", From: day.Add(time.Hour)}, 1},
+		{Filter{SpokenLang: "zh"}, 0},
+	}
+	for _, tt := range tests {
+		got, err := s.Query(tt.f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%+v: got %d snapshots, want %d", tt.f, len(got), tt.want)
+		}
+	}
+
+	got, _ := s.Query(Filter{Lang: "go", Since: "daily"})
+	if !got[0].Time.Equal(day) || !got[1].Time.Equal(day.Add(24*time.Hour)) {
+		t.Fatalf("snapshots should be sorted by time: %v %v", got[0].Time, got[1].Time)
+	}
+	if got[0].Rank("ollama", "ollama") != 2 || got[1].Rank("ollama", "ollama") != 1 || got[1].Rank("golang", "go") != 0 {
+		t.Fatal("wrong rank")
+	}
+
+	prev, err := s.Previous("go", "", "daily", day.Add(24*time.Hour))
+	if err != nil || prev == nil || !prev.Time.Equal(day) {
+		t.Fatalf("previous: %v %v", prev, err)
+	}
+	if prev, _ := s.Previous("go", "", "daily", day.AddDate(1, 0, 0)); prev == nil || !prev.Time.Equal(day.Add(24*time.Hour)) {
+		t.Fatalf("previous should be the latest one: %v", prev)
+	}
+	if prev, _ := s.Previous("go", "", "daily", day); prev != nil {
+		t.Fatalf("nothing before the first one: %v", prev)
+	}
+	if prev, _ := s.Previous("zig", "", "daily", day); prev != nil {
+		t.Fatalf("unknown list: %v", prev)
+	}
+}
+
+func TestStoreLangNames(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "trending.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	repos := []*ghsearch.Repository{{Author: "golang", Name: "go"}}
+	for _, snap := range []*Snapshot{
+		{Lang: "Go", Since: "daily", Time: day, Repos: repos},
+		{Lang: "c#", Since: "daily", Time: day, Repos: repos},
+		{Lang: "Objective-C", SpokenLang: "ZH", Since: "Weekly", Time: day, Repos: repos},
+	} {
+		if err := s.Save(snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	next := day.Add(time.Hour)
+	tests := []struct{ lang, spoken, since string }{
+		{"go", "", "daily"},
+		{"GO", "", "daily"},
+		{"c%23", "", "daily"},
+		{"C#", "", "daily"},
+		{"objective-c", "zh", "weekly"},
+	}
+	for _, tt := range tests {
+		if prev, err := s.Previous(tt.lang, tt.spoken, tt.since, next); err != nil || prev == nil {
+			t.Errorf("previous of %+v: %v %v", tt, prev, err)
+		}
+		got, err := s.Query(Filter{Lang: tt.lang, SpokenLang: tt.spoken, Since: tt.since})
+		if err != nil || len(got) != 1 {
+			t.Errorf("query of %+v: got %d snapshots, %v", tt, len(got), err)
+		}
+	}
+}
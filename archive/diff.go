@@ -0,0 +1,70 @@
+package archive
+
+import "github.com/byebyebruce/ghsearch"
+
+// Change how a repository moved between two snapshots
+type Change string
+
+const (
+	ChangeNew     Change = "new"     // not in previous snapshot
+	ChangeRising  Change = "rising"  // rank goes up
+	ChangeFalling Change = "falling" // rank goes down
+	ChangeSame    Change = "same"
+	ChangeGone    Change = "gone" // not in current snapshot
+)
+
+// DiffEntry one repository compared with previous snapshot
+type DiffEntry struct {
+	Repo     *ghsearch.Repository `json:"repo"` // previous one if gone
+	Change   Change               `json:"change"`
+	Rank     int                  `json:"rank"`      // 0 if gone
+	PrevRank int                  `json:"prev_rank"` // 0 if new
+	// StarDelta stars gained since previous snapshot. it's Add for new ones,
+	// since stars of them in previous snapshot are unknown
+	StarDelta int `json:"star_delta"`
+}
+
+// Diff compare cur with prev. entries are in rank of cur, followed by gone ones in rank of prev.
+// prev may be nil, then all are new
+func Diff(prev, cur *Snapshot) []DiffEntry {
+	type key struct{ author, name string }
+	prevRank := make(map[key]int)
+	if prev != nil {
+		for i, r := range prev.Repos {
+			prevRank[key{r.Author, r.Name}] = i + 1
+		}
+	}
+
+	entries := make([]DiffEntry, 0, len(cur.Repos))
+	seen := make(map[key]bool, len(cur.Repos))
+	for i, r := range cur.Repos {
+		k := key{r.Author, r.Name}
+		seen[k] = true
+		e := DiffEntry{Repo: r, Rank: i + 1, PrevRank: prevRank[k]}
+		switch {
+		case e.PrevRank == 0:
+			e.Change = ChangeNew
+			e.StarDelta = r.Add
+		case e.Rank < e.PrevRank:
+			e.Change = ChangeRising
+		case e.Rank > e.PrevRank:
+			e.Change = ChangeFalling
+		default:
+			e.Change = ChangeSame
+		}
+		if e.PrevRank > 0 {
+			e.StarDelta = r.Stars - prev.Repos[e.PrevRank-1].Stars
+		}
+		entries = append(entries, e)
+	}
+
+	var gone []DiffEntry
+	if prev != nil {
+		for i, r := range prev.Repos {
+			if !seen[key{r.Author, r.Name}] {
+				gone = append(gone, DiffEntry{Repo: r, Change: ChangeGone, PrevRank: i + 1})
+			}
+		}
+	}
+	return append(entries, gone...)
+}
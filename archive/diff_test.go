@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/byebyebruce/ghsearch"
+)
+
+func TestDiff(t *testing.T) {
+	prev := &Snapshot{Repos: []*ghsearch.Repository{
+		{Author: "a", Name: "a", Stars: 100},
+		{Author: "b", Name: "b", Stars: 200},
+		{Author: "c", Name: "c", Stars: 300},
+		{Author: "d", Name: "d", Stars: 400},
+	}}
+	cur := &Snapshot{Repos: []*ghsearch.Repository{
+		{Author: "b", Name: "b", Stars: 250},
+		{Author: "a", Name: "a", Stars: 110},
+		{Author: "e", Name: "e", Stars: 50, Add: 30},
+		{Author: "d", Name: "d", Stars: 400},
+	}}
+	want := []struct {
+		name     string
+		change   Change
+		rank     int
+		prevRank int
+		delta    int
+	}{
+		{"b", ChangeRising, 1, 2, 50},
+		{"a", ChangeFalling, 2, 1, 10},
+		{"e", ChangeNew, 3, 0, 30},
+		{"d", ChangeSame, 4, 4, 0},
+		{"c", ChangeGone, 0, 3, 0},
+	}
+	got := Diff(prev, cur)
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries", len(got))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Repo.Name != w.name || g.Change != w.change || g.Rank != w.rank || g.PrevRank != w.prevRank || g.StarDelta != w.delta {
+			t.Errorf("%d: got %s %s %d %d %d", i, g.Repo.Name, g.Change, g.Rank, g.PrevRank, g.StarDelta)
+		}
+	}
+
+	for _, e := range Diff(nil, cur) {
+		if e.Change != ChangeNew {
+			t.Fatalf("%s should be new without previous snapshot", e.Repo.Name)
+		}
+	}
+}
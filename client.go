@@ -0,0 +1,211 @@
+package ghsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// DefaultBaseURL github api address
+	DefaultBaseURL = "https://api.github.com"
+	// DefaultUserAgent user agent sent with every request
+	DefaultUserAgent = "ghsearch"
+)
+
+// Client github api client, safe for concurrent use
+type Client struct {
+	token      string
+	baseURL    string
+	webURL     string
+	userAgent  string
+	timeout    time.Duration
+	httpClient *http.Client
+
+	maxRetries   int
+	maxRetryWait time.Duration
+
+	hc *http.Client
+	rc *resty.Client
+}
+
+// Option configures a Client
+type Option func(c *Client)
+
+// WithToken set github api token
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithBaseURL set api base url. default https://api.github.com
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithWebURL set web base url which trending pages are fetched from. default https://github.com
+func WithWebURL(webURL string) Option {
+	return func(c *Client) {
+		c.webURL = strings.TrimRight(webURL, "/")
+	}
+}
+
+// WithHost set both api and web base url from a github host, e.g. github.com or ghe.corp
+func WithHost(host string) Option {
+	return func(c *Client) {
+		c.baseURL, c.webURL = HostURLs(host)
+	}
+}
+
+// WithHTTPClient use a custom http client, so connection pools and transports can be shared
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithUserAgent set User-Agent header
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithTimeout set request timeout. 0 means no timeout
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// NewClient create a client
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:   DefaultBaseURL,
+		webURL:    GitHubURL,
+		userAgent: DefaultUserAgent,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+
+	hc := &http.Client{}
+	if c.httpClient != nil {
+		// copy it, so setting timeout doesn't change the caller's client
+		cp := *c.httpClient
+		hc = &cp
+	}
+	if c.timeout > 0 {
+		hc.Timeout = c.timeout
+	}
+	c.hc = hc
+	c.rc = resty.NewWithClient(hc)
+	c.rc.SetHeader("Accept", "application/vnd.github+json")
+	c.rc.SetHeader("User-Agent", c.userAgent)
+	if len(c.token) > 0 {
+		c.rc.SetAuthToken(c.token)
+	}
+	return c
+}
+
+// BaseURL api base url
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// WebURL web base url
+func (c *Client) WebURL() string {
+	return c.webURL
+}
+
+// HostURLs return api and web base url of a github host.
+// github.com => https://api.github.com, https://github.com
+// ghe.corp => https://ghe.corp/api/v3, https://ghe.corp
+func HostURLs(host string) (apiURL, webURL string) {
+	scheme := "https://"
+	if i := strings.Index(host, "://"); i >= 0 {
+		scheme, host = host[:i+3], host[i+3:]
+	}
+	host = strings.TrimRight(host, "/")
+	if host == "" || host == "github.com" || host == "api.github.com" {
+		return DefaultBaseURL, GitHubURL
+	}
+	return scheme + host + "/api/v3", scheme + host
+}
+
+// WebURLFromAPI guess web base url from api base url
+// https://api.github.com => https://github.com
+// https://ghe.corp/api/v3 => https://ghe.corp
+func WebURLFromAPI(apiURL string) string {
+	apiURL = strings.TrimRight(apiURL, "/")
+	if strings.HasSuffix(apiURL, "/api/v3") {
+		return strings.TrimSuffix(apiURL, "/api/v3")
+	}
+	return strings.Replace(apiURL, "://api.", "://", 1)
+}
+
+// resolve api path into url. absolute url from api responses is allowed only under base url,
+// so token is never sent to other hosts
+func (c *Client) resolve(path string) (string, error) {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		return c.baseURL + path, nil
+	}
+	if !strings.HasPrefix(path, c.baseURL+"/") {
+		return "", fmt.Errorf("url %s is not under api base url %s", path, c.baseURL)
+	}
+	return path, nil
+}
+
+// get request api path and decode json body into out.
+// wait and retry if rate limited and WithRateLimitRetry is set
+func (c *Client) get(ctx context.Context, path string, out interface{}) (*Response, error) {
+	return c.getAccept(ctx, path, "", out)
+}
+
+// getAccept like get, with custom Accept header. empty accept means default.
+// out can be *[]byte to get raw body
+func (c *Client) getAccept(ctx context.Context, path string, accept string, out interface{}) (*Response, error) {
+	u, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	for retry := 0; ; retry++ {
+		req := c.rc.R().SetContext(ctx)
+		if len(accept) > 0 {
+			req.SetHeader("Accept", accept)
+		}
+		resp, err := req.Get(u)
+		if err != nil {
+			return nil, err
+		}
+		rate := parseRateLimit(resp.Header())
+		if resp.StatusCode() != http.StatusOK {
+			limited, wait := rateLimited(resp.StatusCode(), rate, resp.Body())
+			if limited && retry < c.maxRetries && wait <= c.maxRetryWait {
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, newAPIError(resp.StatusCode(), rate, resp.Body())
+		}
+		if raw, ok := out.(*[]byte); ok {
+			*raw = resp.Body()
+		} else if err := json.Unmarshal(resp.Body(), out); err != nil {
+			return nil, err
+		}
+		return &Response{
+			StatusCode: resp.StatusCode(),
+			Links:      parseLinks(resp.Header()),
+			Rate:       rate,
+		}, nil
+	}
+}
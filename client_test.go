@@ -0,0 +1,76 @@
+package ghsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/repositories" {
+			t.Errorf("path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer abc" {
+			t.Errorf("Authorization %s", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("User-Agent %s", got)
+		}
+		if got := r.URL.Query(); got.Get("q") != "grpc language:go" || got.Get("sort") != "stars" || got.Get("order") != "desc" {
+			t.Errorf("query %v", got)
+		}
+		w.Write([]byte(`{"total_count":2,"items":[{"name":"a","stargazers_count":1},{"name":"b","stargazers_count":2}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithToken("abc"), WithBaseURL(srv.URL+"/"), WithUserAgent("test-agent"), WithHTTPClient(srv.Client()))
+	r, err := c.SearchRepo(1, "go", "grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 2 || r[0].Name != "a" {
+		t.Fatalf("server order should be kept %+v", r)
+	}
+
+	_, err = c.SearchCodeQuery(context.Background(), NewQuery("x"), &SearchOptions{Sort: SortStars})
+	if err == nil {
+		t.Fatal("stars is not a valid code sort")
+	}
+}
+
+func TestHostURLs(t *testing.T) {
+	cases := []struct {
+		host, api, web string
+	}{
+		{"", "https://api.github.com", "https://github.com"},
+		{"github.com", "https://api.github.com", "https://github.com"},
+		{"ghe.corp", "https://ghe.corp/api/v3", "https://ghe.corp"},
+		{"http://ghe.corp/", "http://ghe.corp/api/v3", "http://ghe.corp"},
+	}
+	for _, v := range cases {
+		api, web := HostURLs(v.host)
+		if api != v.api || web != v.web {
+			t.Errorf("%s => %s %s", v.host, api, web)
+		}
+		if got := WebURLFromAPI(api); got != v.web {
+			t.Errorf("WebURLFromAPI %s => %s", api, got)
+		}
+	}
+}
+
+func TestSearchRepoContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.SearchRepoContext(ctx, 1, "go", "grpc"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+}
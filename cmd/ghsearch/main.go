@@ -2,35 +2,46 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/output"
 	"github.com/byebyebruce/ghsearch/util"
-	ui "github.com/gizak/termui/v3"
-	"github.com/gizak/termui/v3/widgets"
 	"github.com/spf13/cobra"
 )
 
-const (
-	COUNT_PER_PAGE = 30
-)
-
 // GITHUB_TOKEN api token go build -ldflags "-X main.Version=$(TOKEN)"
 var GITHUB_TOKEN string
 
 func main() {
 	var (
-		lang  string
-		token string
-		code  bool // false:search repo, true:search code
+		lang      string
+		token     string
+		host      string
+		code      bool // false:search repo, true:search code
+		issues    bool // search issues and pull requests
+		users     bool // search users and organizations
+		commits   bool // search commits
+		topics    bool // search topics
+		sortName  string
+		orderName string
+		perPage   int
+		rlWait    time.Duration
+		format    string
+		tmpl      string
+		limit     int
 	)
 	rootCmd := &cobra.Command{
-		Use:          "ghsearch",
-		Short:        "github repo search",
-		SilenceUsage: true,
+		Use:           "ghsearch [flags] [--] keywords...",
+		Short:         "github repo search",
+		SilenceUsage:  true,
+		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, osArgs []string) error {
 			if len(token) == 0 {
 				token = GITHUB_TOKEN
@@ -43,10 +54,84 @@ func main() {
 				return fmt.Errorf("token is emtpy. please set env GITHUB_TOKEN or use --token=xx")
 			}
 
+			sortBy, err := ghsearch.ParseSort(sortName)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("sort") && !code && !issues && !users && !commits && !topics {
+				sortBy = ghsearch.SortStars
+			}
+			orderBy, err := ghsearch.ParseOrder(orderName)
+			if err != nil {
+				return err
+			}
+			if perPage <= 0 || perPage > ghsearch.MaxPerPage {
+				return fmt.Errorf("per-page should be in [1,%d]", ghsearch.MaxPerPage)
+			}
+			// print results without terminal ui when asked or piped
+			var pr *printer
+			if len(format) > 0 || len(tmpl) > 0 || !output.IsTerminal(os.Stdout) {
+				f := output.TSV
+				if len(tmpl) > 0 {
+					f = output.Template
+				}
+				if len(format) > 0 {
+					if f, err = output.ParseFormat(format); err != nil {
+						return err
+					}
+				}
+				if limit <= 0 {
+					return fmt.Errorf("limit should be positive")
+				}
+				pr = &printer{format: f, text: tmpl, limit: limit}
+				if !cmd.Flags().Changed("per-page") && limit < ghsearch.MaxPerPage {
+					perPage = limit
+				} else if !cmd.Flags().Changed("per-page") {
+					perPage = ghsearch.MaxPerPage
+				}
+			}
+			opt := ghsearch.SearchOptions{Sort: sortBy, Order: orderBy, PerPage: perPage}
+			repoLang := lang
+			if !cmd.Flags().Changed("lang") && (users || commits || topics) {
+				// default language is for repo, code and issue search. users are rarely meant to be
+				// narrowed to it, commit and topic search don't support language qualifier
+				lang = ""
+			}
+
 			var (
-				err error
+				apiURL, webURL = util.GitHubURLs(host)
+				client         = ghsearch.NewClient(
+					ghsearch.WithToken(token),
+					ghsearch.WithBaseURL(apiURL),
+					ghsearch.WithWebURL(webURL),
+					ghsearch.WithRateLimitRetry(3, rlWait),
+				)
 			)
 
+			if pr != nil {
+				if len(osArgs) == 0 {
+					return fmt.Errorf("key words are required with --format")
+				}
+				q, err := ghsearch.KeywordsQuery(lang, osArgs...)
+				if err != nil {
+					return err
+				}
+				kind := ""
+				switch {
+				case code:
+					kind = "code"
+				case issues:
+					kind = "issues"
+				case users:
+					kind = "users"
+				case commits:
+					kind = "commits"
+				case topics:
+					kind = "topics"
+				}
+				return printResults(cmd.Context(), *pr, client, kind, q, opt)
+			}
+
 			args := osArgs
 			for {
 				if len(args) == 0 {
@@ -63,10 +148,23 @@ func main() {
 					}
 					args = strings.Split(words, " ")
 				}
-				if code {
-					err = searchCode(token, lang, args...)
-				} else {
-					err = searchRepo(token, lang, args...)
+				q, err := ghsearch.KeywordsQuery(lang, args...)
+				if err != nil {
+					return err
+				}
+				switch {
+				case code:
+					err = searchCode(client, q, opt)
+				case issues:
+					err = searchIssues(client, q, opt)
+				case users:
+					err = searchUsers(client, q, opt)
+				case commits:
+					err = searchCommits(client, q, opt)
+				case topics:
+					err = searchTopics(client, q, opt, repoLang)
+				default:
+					err = searchRepo(client, q, opt)
 				}
 				args = args[:0]
 				if err != nil {
@@ -77,317 +175,364 @@ func main() {
 	}
 	rootCmd.Flags().StringVar(&lang, "lang", "go", "language")
 	rootCmd.Flags().StringVar(&token, "token", "", "github api token")
+	rootCmd.Flags().StringVar(&host, "host", "", "github host, e.g. ghe.corp. default env GITHUB_API_URL or GH_HOST or github.com")
 	rootCmd.Flags().BoolVar(&code, "code", false, "false:search repo, true:search code")
+	rootCmd.Flags().BoolVar(&issues, "issues", false, "search issues and pull requests")
+	rootCmd.Flags().BoolVar(&users, "users", false, "search users and organizations, e.g. type:org followers:>1000")
+	rootCmd.Flags().BoolVar(&commits, "commits", false, "search commits, e.g. repo:golang/go author:rsc")
+	rootCmd.Flags().BoolVar(&topics, "topics", false, "search topics, enter to browse repositories of the topic")
+	rootCmd.MarkFlagsMutuallyExclusive("code", "issues", "users", "commits", "topics")
+	rootCmd.Flags().StringVar(&sortName, "sort", "", "repo: best-match/stars/forks/help-wanted-issues/updated (default stars), code: best-match/indexed, issues: best-match/comments/reactions/interactions/created/updated, users: best-match/followers/repositories/joined, commits: best-match/author-date/committer-date")
+	rootCmd.Flags().StringVar(&orderName, "order", "desc", "asc/desc")
+	rootCmd.Flags().DurationVar(&rlWait, "rate-limit-wait", time.Minute, "max time to wait and retry when rate limited, 0 means no retry")
+	rootCmd.Flags().IntVar(&perPage, "per-page", ghsearch.DefaultPerPage, "results per page, max 100")
+	rootCmd.Flags().StringVar(&format, "format", "", "print results without terminal ui: json/ndjson/csv/tsv/table/markdown/template. default tsv when stdout is not a terminal")
+	rootCmd.Flags().StringVar(&tmpl, "template", "", "go text/template executed per result, e.g. '{{.FullName}} {{.StargazersCount}}'")
+	rootCmd.Flags().IntVar(&limit, "limit", ghsearch.DefaultPerPage, "max results to print with --format")
+	// flags go before keywords, so negated qualifiers like -topic:bot after them are not taken as flags
+	rootCmd.Flags().SetInterspersed(false)
 
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Println(explain(err))
 		os.Exit(1)
 	}
 }
 
-func searchRepo(token, lang string, args ...string) error {
-	if err := ui.Init(); err != nil {
-		return err
+// explain turn api error into friendly message
+func explain(err error) string {
+	var apiErr *ghsearch.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
 	}
-	defer ui.Close()
-
-	defer ui.Clear()
-
-LOOP:
-	for {
-		var (
-			page = 1
-		)
-		ui.Clear()
-
-		ret, err := util.AsyncTaskAndShowLoadingBar("loading", func() ([]ghsearch.SearchRepoResultItems, error) {
-			return ghsearch.SearchRepo(token, page, lang, args...)
-		})
-		if err != nil {
-			return err
+	switch {
+	case ghsearch.IsUnauthorized(err):
+		return "bad credentials. please check env GITHUB_TOKEN or --token"
+	case ghsearch.IsRateLimited(err):
+		msg := "rate limited by github, please try again later"
+		if !apiErr.Rate.Reset.IsZero() {
+			msg += ". quota resets at " + apiErr.Rate.Reset.Format(time.Kitchen)
 		}
-		if len(ret) == 0 {
-			fmt.Println("no result")
-			time.Sleep(time.Second * 2)
-			return nil
-		}
-
-		// title
-		title := widgets.NewParagraph()
-		title.Title = "Search"
-		title.TextStyle.Fg = ui.ColorWhite
-		title.Text = strings.Join(args, " ")
-
-		// input
-		input := widgets.NewParagraph()
-		input.Title = fmt.Sprintf("j/k: up/down, enter: open, q: quit, ctrl+n/p: next/privious page")
-		input.TitleStyle = ui.NewStyle(ui.ColorCyan)
-		input.Border = false
+		return msg
+	case ghsearch.IsValidationFailed(err):
+		return "invalid search query: " + err.Error()
+	case ghsearch.IsForbidden(err):
+		return "forbidden, the token may lack permission: " + err.Error()
+	case ghsearch.IsServerError(err):
+		return "github is having trouble, please try again later: " + err.Error()
+	}
+	return err.Error()
+}
 
-		// list
-		l := widgets.NewList()
-		l.Title = "Repo"
-		for i, v := range ret {
-			l.Rows = append(l.Rows, fmt.Sprintf("%2d ⭐%-6d %s/%s", (page-1)*COUNT_PER_PAGE+i+1, v.StargazersCount, v.Owner.Login, v.Name))
-		}
-		l.SelectedRowStyle = ui.NewStyle(ui.ColorWhite, ui.ColorCyan)
-		l.TextStyle = ui.NewStyle(ui.ColorWhite)
-		l.WrapText = false
+func searchRepo(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	return browse(q.String(), opt.PerPage, repoView(client, q, opt))
+}
 
-		// desc
-		p := widgets.NewParagraph()
-		p.Title = "Desc"
-		p.TextStyle.Fg = ui.ColorGreen
-		p.BorderStyle.Fg = ui.ColorCyan
-		showDesc := func(idx int) {
-			l.Title = fmt.Sprintf("Page:%d", page)
-			current := ret[idx]
-			updateAt := ret[idx].PushedAt.Format(time.RFC3339)
-			p.Text = fmt.Sprintf(`[Project: %s](fg:white,mod:bold)
+func repoView(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) view[ghsearch.SearchRepoResultItems] {
+	return view[ghsearch.SearchRepoResultItems]{
+		name: "Repo",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchRepoResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchRepoQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchRepoResultItems) string {
+			return fmt.Sprintf("⭐%-6d %s/%s", v.StargazersCount, v.Owner.Login, v.Name)
+		},
+		desc: func(current ghsearch.SearchRepoResultItems) string {
+			updateAt := current.PushedAt.Format(time.RFC3339)
+			return fmt.Sprintf(`[Project: %s](fg:white,mod:bold)
 [Author: %s](fg:red)
 [Link: %s](fg:blue)
 [Last Update: %s](fg:blue)
+[Topics: %s](fg:yellow)
 Desc: 
     %s
-`, current.Name, current.Owner.Login, current.HTMLURL, updateAt, current.Description)
-		}
-
-		grid := ui.NewGrid()
-		termWidth, termHeight := ui.TerminalDimensions()
-		grid.Set(
-			ui.NewCol(0.4, l),
-			ui.NewCol(0.6, p),
-		)
-
-		onResize := func(w, h int) {
-			const titleOffset = 3
-			const inputOffset = 1
-			title.SetRect(0, 0, w, titleOffset)
-			grid.SetRect(0, titleOffset, w, h-inputOffset)
-			input.SetRect(0, h-inputOffset, w, h)
-		}
-
-		showDesc(l.SelectedRow)
-		onResize(termWidth, termHeight)
-		ui.Render(title, input, grid)
-
-		previousKey := ""
-		uiEvents := ui.PollEvents()
-
-		for {
-			e := <-uiEvents
-			switch e.ID {
-			case "q":
-				return nil
-			case "<C-c>":
-				ui.Close()
-				os.Exit(0)
-			case "j", "<Down>":
-				l.ScrollDown()
-				showDesc(l.SelectedRow)
-			case "k", "<Up>":
-				l.ScrollUp()
-				showDesc(l.SelectedRow)
-			case "<C-d>":
-				l.ScrollHalfPageDown()
-			case "<C-u>":
-				l.ScrollHalfPageUp()
-			case "<C-f>":
-				l.ScrollPageDown()
-			case "<C-b>":
-				l.ScrollPageUp()
-			case "<C-n>":
-				page += 1
-				continue LOOP
-			case "<C-p>":
-				if page > 1 {
-					page -= 1
-					continue LOOP
-				}
-			case "g":
-				if previousKey == "g" {
-					l.ScrollTop()
-				}
-			case "<Home>":
-				l.ScrollTop()
-			case "G", "<End>":
-				l.ScrollBottom()
-			case "<Enter>":
-				link := ret[l.SelectedRow].HTMLURL
-				util.OpenWebBrowser(link)
-			case "<Resize>":
-				payload := e.Payload.(ui.Resize)
-				onResize(payload.Width, payload.Height)
-				ui.Clear()
-			}
-
-			if previousKey == "g" {
-				previousKey = ""
-			} else {
-				previousKey = e.ID
-			}
-
-			ui.Render(title, input, grid)
-		}
-
+`, current.Name, current.Owner.Login, current.HTMLURL, updateAt, strings.Join(current.Topics, ", "), current.Description)
+		},
+		link: func(v ghsearch.SearchRepoResultItems) string {
+			return v.HTMLURL
+		},
+		key: func(v ghsearch.SearchRepoResultItems) string {
+			return v.FullName
+		},
+		alt: func(ctx context.Context, v ghsearch.SearchRepoResultItems) ([]string, error) {
+			return readme(ctx, client, v)
+		},
+		altName: "README",
 	}
-	return nil
 }
-func searchCode(token, lang string, args ...string) error {
-	if err := ui.Init(); err != nil {
-		return err
-	}
-	defer ui.Close()
-
-	defer ui.Clear()
-
-LOOP:
-	for {
-		var (
-			page = 1
-		)
-		ui.Clear()
-
-		ret, err := util.AsyncTaskAndShowLoadingBar("loading", func() ([]ghsearch.SearchCodeResultItems, error) {
-			return ghsearch.SearchCode(token, 0, lang, args...)
-		})
-		if err != nil {
-			return err
-		}
-		if err != nil {
-			return err
-		}
-		if len(ret) == 0 {
-			fmt.Println("no result")
-			time.Sleep(time.Second * 2)
-			return nil
-		}
-
-		// title
-		title := widgets.NewParagraph()
-		title.Title = "Search"
-		title.TextStyle.Fg = ui.ColorWhite
-		title.Text = strings.Join(args, " ")
-
-		// input
-		input := widgets.NewParagraph()
-		input.Title = fmt.Sprintf("j/k: up/down, enter: open, q: quit, ctrl+n/p: next/privious page")
-		input.TitleStyle = ui.NewStyle(ui.ColorCyan)
-		input.Border = false
 
-		// list
-		l := widgets.NewList()
-		l.Title = "Repo"
-		for i, v := range ret {
-			l.Rows = append(l.Rows, fmt.Sprintf("%2d %s [%s](fg:yellow)", (page-1)*COUNT_PER_PAGE+i+1, v.Repository.Name, v.Name))
-		}
-		l.SelectedRowStyle = ui.NewStyle(ui.ColorWhite, ui.ColorCyan)
-		l.TextStyle = ui.NewStyle(ui.ColorWhite)
-		l.WrapText = false
-
-		// desc
-		p := widgets.NewParagraph()
-		p.Title = "Desc"
-		p.TextStyle.Fg = ui.ColorGreen
-		p.BorderStyle.Fg = ui.ColorCyan
-		p.WrapText = true
-		showDesc := func(idx int) {
-			l.Title = fmt.Sprintf("Page:%d", page)
-			current := ret[idx]
-			p.Text = fmt.Sprintf(`%s
+func searchCode(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	opt.TextMatch = true
+	return browse(q.String(), opt.PerPage, view[ghsearch.SearchCodeResultItems]{
+		name: "Code",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchCodeResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchCodeQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchCodeResultItems) string {
+			return fmt.Sprintf("%s [%s](fg:yellow)", v.Repository.Name, v.Name)
+		},
+		desc: func(current ghsearch.SearchCodeResultItems) string {
+			return fmt.Sprintf(`%s
 [Project: %s](fg:white,mod:bold)
 [Author: %s](fg:red)
 [File: %s](fg:yellow)
 [Score: %02f](fg:blue)
 Desc:
     %s
-`,
+Matches:
+%s`,
 				current.HTMLURL,
 				current.Repository.Name,
 				current.Repository.Owner.Login,
 				current.Path,
 				current.Score,
-				current.Repository.Description)
-		}
-
-		grid := ui.NewGrid()
-		termWidth, termHeight := ui.TerminalDimensions()
-		grid.Set(
-			ui.NewCol(0.4, l),
-			ui.NewCol(0.6, p),
-		)
+				current.Repository.Description,
+				highlight(current.TextMatches))
+		},
+		link: func(v ghsearch.SearchCodeResultItems) string {
+			return v.HTMLURL
+		},
+		key: func(v ghsearch.SearchCodeResultItems) string {
+			return v.Sha
+		},
+		preview: func(ctx context.Context, v ghsearch.SearchCodeResultItems) ([]string, int, error) {
+			return previewCode(ctx, client, v)
+		},
+	})
+}
 
-		onResize := func(w, h int) {
-			const titleOffset = 3
-			const inputOffset = 1
-			title.SetRect(0, 0, w, titleOffset)
-			grid.SetRect(0, titleOffset, w, h-inputOffset)
-			input.SetRect(0, h-inputOffset, w, h)
-		}
+func searchIssues(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	return browse(q.String(), opt.PerPage, view[ghsearch.SearchIssueResultItems]{
+		name: "Issue",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchIssueResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchIssuesQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchIssueResultItems) string {
+			kind := "issue"
+			if v.IsPullRequest() {
+				kind = "pr"
+			}
+			return fmt.Sprintf("%-5s %-6s %s#%d %s", kind, v.State, v.RepositoryName(), v.Number, v.Title)
+		},
+		desc: func(current ghsearch.SearchIssueResultItems) string {
+			var labels, assignees []string
+			for _, l := range current.Labels {
+				labels = append(labels, l.Name)
+			}
+			for _, a := range current.Assignees {
+				assignees = append(assignees, a.Login)
+			}
+			kind := "Issue"
+			if current.IsPullRequest() {
+				kind = "Pull Request"
+			}
+			stateColor := "green"
+			if current.State != "open" {
+				stateColor = "red"
+			}
+			return fmt.Sprintf(`[#%d %s](fg:white,mod:bold)
+[Repo: %s](fg:red)
+[Author: %s](fg:red)
+[%s: %s](fg:%s)
+[Labels: %s](fg:yellow)
+[Assignees: %s](fg:blue)
+[Comments: %d](fg:blue)
+[Created: %s](fg:blue)
+[Last Update: %s](fg:blue)
+Body:
+    %s
+`,
+				current.Number, current.Title,
+				current.RepositoryName(),
+				current.User.Login,
+				kind, current.State, stateColor,
+				strings.Join(labels, ", "),
+				strings.Join(assignees, ", "),
+				current.Comments,
+				current.CreatedAt.Format(time.RFC3339),
+				current.UpdatedAt.Format(time.RFC3339),
+				truncate(current.Body, 2000))
+		},
+		link: func(v ghsearch.SearchIssueResultItems) string {
+			return v.HTMLURL
+		},
+	})
+}
 
-		showDesc(l.SelectedRow)
-		onResize(termWidth, termHeight)
-		ui.Render(title, input, grid)
+func searchUsers(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	return browse(q.String(), opt.PerPage, view[ghsearch.SearchUserResultItems]{
+		name: "User",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchUserResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchUsersQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchUserResultItems) string {
+			return fmt.Sprintf("%-12s %s", v.Type, v.Login)
+		},
+		desc: func(current ghsearch.SearchUserResultItems) string {
+			return fmt.Sprintf(`[%s](fg:white,mod:bold)
+[Type: %s](fg:red)
+[Link: %s](fg:blue)
+`, current.Login, current.Type, current.HTMLURL)
+		},
+		link: func(v ghsearch.SearchUserResultItems) string {
+			return v.HTMLURL
+		},
+		key: func(v ghsearch.SearchUserResultItems) string {
+			return v.Login
+		},
+		detail: func(ctx context.Context, v ghsearch.SearchUserResultItems) (string, error) {
+			u, err := client.GetUser(ctx, v.Login)
+			if err != nil {
+				return "", err
+			}
+			repos, err := client.ListUserRepos(ctx, v.Login, ghsearch.MaxPerPage)
+			if err != nil {
+				return "", err
+			}
+			// top repositories among recently pushed ones
+			sort.SliceStable(repos, func(i, j int) bool {
+				return repos[i].StargazersCount > repos[j].StargazersCount
+			})
+			const topN = 5
+			if len(repos) > topN {
+				repos = repos[:topN]
+			}
+			var top strings.Builder
+			for _, r := range repos {
+				fmt.Fprintf(&top, "    ⭐%-6d %s\n", r.StargazersCount, r.Name)
+			}
+			return fmt.Sprintf(`[Name: %s](fg:white)
+[Followers: %d  Following: %d  Repos: %d](fg:yellow)
+[Location: %s](fg:blue)
+[Company: %s](fg:blue)
+[Blog: %s](fg:blue)
+[Joined: %s](fg:blue)
+Bio:
+    %s
+Top Repositories:
+%s`,
+				u.Name,
+				u.Followers, u.Following, u.PublicRepos,
+				u.Location,
+				u.Company,
+				u.Blog,
+				u.CreatedAt.Format("2006-01-02"),
+				u.Bio,
+				top.String()), nil
+		},
+	})
+}
 
-		previousKey := ""
-		uiEvents := ui.PollEvents()
+func searchCommits(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	return browse(q.String(), opt.PerPage, view[ghsearch.SearchCommitResultItems]{
+		name: "Commit",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchCommitResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchCommitsQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchCommitResultItems) string {
+			return fmt.Sprintf("[%s](fg:yellow) %s %s", v.ShortSha(), v.Commit.Author.Date.Format("2006-01-02"), v.Title())
+		},
+		desc: func(current ghsearch.SearchCommitResultItems) string {
+			author := current.Commit.Author.Name
+			if current.Author != nil {
+				author += " (" + current.Author.Login + ")"
+			}
+			return fmt.Sprintf(`[Commit: %s](fg:white,mod:bold)
+[Repo: %s](fg:red)
+[Author: %s <%s>](fg:red)
+[Date: %s](fg:blue)
+[Link: %s](fg:blue)
+Message:
+    %s
+`,
+				current.Sha,
+				current.Repository.FullName,
+				author, current.Commit.Author.Email,
+				current.Commit.Author.Date.Format(time.RFC3339),
+				current.HTMLURL,
+				strings.ReplaceAll(truncate(current.Commit.Message, 2000), "\n", "\n    "))
+		},
+		link: func(v ghsearch.SearchCommitResultItems) string {
+			return v.HTMLURL
+		},
+	})
+}
 
-		for {
-			e := <-uiEvents
-			switch e.ID {
-			case "q":
-				return nil
-			case "<C-c>":
-				ui.Close()
-				os.Exit(0)
-			case "j", "<Down>":
-				l.ScrollDown()
-				showDesc(l.SelectedRow)
-			case "k", "<Up>":
-				l.ScrollUp()
-				showDesc(l.SelectedRow)
-			case "<C-d>":
-				l.ScrollHalfPageDown()
-			case "<C-u>":
-				l.ScrollHalfPageUp()
-			case "<C-f>":
-				l.ScrollPageDown()
-			case "<C-b>":
-				l.ScrollPageUp()
-			case "<C-n>":
-				page += 1
-				continue LOOP
-			case "<C-p>":
-				if page > 1 {
-					page -= 1
-					continue LOOP
-				}
-			case "g":
-				if previousKey == "g" {
-					l.ScrollTop()
-				}
-			case "<Home>":
-				l.ScrollTop()
-			case "G", "<End>":
-				l.ScrollBottom()
-			case "<Enter>":
-				link := ret[l.SelectedRow].HTMLURL
-				util.OpenWebBrowser(link)
-			case "<Resize>":
-				payload := e.Payload.(ui.Resize)
-				onResize(payload.Width, payload.Height)
-				ui.Clear()
+// searchTopics enter a topic to browse its repositories in lang, sorted by stars
+func searchTopics(client *ghsearch.Client, q *ghsearch.Query, opt ghsearch.SearchOptions, lang string) error {
+	return browse(q.String(), opt.PerPage, view[ghsearch.SearchTopicResultItems]{
+		name: "Topic",
+		fetch: func(ctx context.Context, page int) (*ghsearch.SearchTopicResult, error) {
+			o := opt
+			o.Page = page
+			return client.SearchTopicsQuery(ctx, q, &o)
+		},
+		row: func(v ghsearch.SearchTopicResultItems) string {
+			mark := " "
+			if v.Featured || v.Curated {
+				mark = "★"
+			}
+			return fmt.Sprintf("%s %s", mark, v.Name)
+		},
+		desc: func(current ghsearch.SearchTopicResultItems) string {
+			desc := current.Description
+			if len(desc) == 0 {
+				desc = current.ShortDescription
+			}
+			return fmt.Sprintf(`[Topic: %s](fg:white,mod:bold)
+[Name: %s](fg:red)
+[Created By: %s](fg:blue)
+[Released: %s](fg:blue)
+[Featured: %t  Curated: %t](fg:yellow)
+[Enter: browse repositories](fg:cyan)
+Desc:
+    %s
+`, current.DisplayName, current.Name, current.CreatedBy, current.Released, current.Featured, current.Curated, desc)
+		},
+		link: func(v ghsearch.SearchTopicResultItems) string {
+			return client.WebURL() + "/topics/" + v.Name
+		},
+		enter: func(s *screen, v ghsearch.SearchTopicResultItems) error {
+			rq := ghsearch.NewQuery().Topic(v.Name)
+			if len(lang) > 0 {
+				rq.Language(lang)
 			}
+			ro := ghsearch.SearchOptions{Sort: ghsearch.SortStars, Order: ghsearch.OrderDesc, PerPage: opt.PerPage}
+			return show(s, rq.String(), ro.PerPage, repoView(client, rq, ro))
+		},
+	})
+}
 
-			if previousKey == "g" {
-				previousKey = ""
+// highlight render matched fragments, matched text is highlighted
+func highlight(matches []ghsearch.TextMatch) string {
+	var b strings.Builder
+	for _, m := range matches {
+		for _, seg := range m.Segments() {
+			if seg.Match && len(strings.TrimSpace(seg.Text)) > 0 {
+				fmt.Fprintf(&b, "[%s](fg:black,bg:yellow)", escapeMarkup(seg.Text))
 			} else {
-				previousKey = e.ID
+				b.WriteString(escapeMarkup(seg.Text))
 			}
-
-			ui.Render(title, input, grid)
 		}
+		b.WriteString("\n[────────](fg:cyan)\n")
+	}
+	return b.String()
+}
 
+// truncate s to at most n runes
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
 	}
-	return nil
+	return string(r[:n]) + "..."
 }
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/byebyebruce/ghsearch"
+	ui "github.com/gizak/termui/v3"
+)
+
+func TestHighlight(t *testing.T) {
+	var matches []ghsearch.TextMatch
+	err := json.Unmarshal([]byte(`[
+		{"fragment": "func f(b []byte) error", "matches": [
+			{"text": "[]byte", "indices": [9, 15]},
+			{"text": "error", "indices": [17, 22]}
+		]},
+		{"fragment": "m[k](v)\nfoo = [\n  bar", "matches": [{"text": "bar", "indices": [18, 21]}]}
+	]`), &matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := highlight(matches)
+	sep := "\n────────\n"
+	if want := "func f(b []byte) error" + sep + "m[k](v)\nfoo = [\n  bar" + sep; visibleText(got) != want {
+		t.Errorf("got %q, want %q", visibleText(got), want)
+	}
+
+	// matched text is highlighted
+	var hl []string
+	for _, c := range ui.ParseStyles(got, ui.StyleClear) {
+		if c.Style.Bg == ui.ColorYellow {
+			hl = append(hl, string(c.Rune))
+		}
+	}
+	if want := "⁅⁆byteerrorbar"; strings.Join(hl, "") != want {
+		t.Errorf("highlighted %q, want %q", strings.Join(hl, ""), want)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/byebyebruce/ghsearch"
+)
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)[\s#]*$`)
+	mdList    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTask    = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	mdRule    = regexp.MustCompile(`^\s*([-*_]\s*){3,}$`)
+	mdTable   = regexp.MustCompile(`^\s*\|?(\s*:?-+:?\s*\|)+\s*:?-*:?\s*$`)
+	mdImage   = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdHTML    = regexp.MustCompile(`<[^>]*>`)
+	mdInline  = regexp.MustCompile("`[^`]+`|\\[[^\\]]+\\]\\([^)]*\\)|\\*\\*[^*]+\\*\\*|__[^_]+__")
+)
+
+// readme fetch README of repository and render it
+func readme(ctx context.Context, client *ghsearch.Client, item ghsearch.SearchRepoResultItems) ([]string, error) {
+	b, err := client.GetReadme(ctx, item.Owner.Login, item.Name)
+	if ghsearch.IsNotFound(err) {
+		return []string{"[no README](fg:yellow)"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return renderMarkdown(string(b)), nil
+}
+
+// renderMarkdown turn markdown into lines of termui styled text.
+// only headings, lists, quotes, code blocks, links and emphasis are styled, html tags are dropped
+func renderMarkdown(src string) []string {
+	var (
+		lines  []string
+		fence  string // fence of current code block, empty if not in code block
+		blanks int
+	)
+	add := func(line string) {
+		if len(strings.TrimSpace(line)) == 0 {
+			// collapse blank lines
+			blanks++
+			if blanks > 1 || len(lines) == 0 {
+				return
+			}
+		} else {
+			blanks = 0
+		}
+		lines = append(lines, line)
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+
+		if len(fence) > 0 {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				add("")
+				continue
+			}
+			add(style("    "+line, "fg:yellow"))
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			add("")
+			continue
+		}
+
+		switch {
+		case mdHeading.MatchString(trimmed):
+			m := mdHeading.FindStringSubmatch(trimmed)
+			text := plainInline(m[2])
+			if len(m[1]) <= 2 {
+				text = strings.ToUpper(text)
+			}
+			add("")
+			add(style(text, "fg:cyan,mod:bold"))
+		case mdRule.MatchString(trimmed):
+			add(style("────────", "fg:cyan"))
+		case mdTable.MatchString(trimmed):
+			// table delimiter row
+		case mdList.MatchString(line):
+			m := mdList.FindStringSubmatch(line)
+			bullet, item := "•", m[3]
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				bullet = m[2]
+			}
+			if t := mdTask.FindStringSubmatch(item); t != nil {
+				bullet = "☐"
+				if t[1] != " " {
+					bullet = "☑"
+				}
+				item = item[len(t[0]):]
+			}
+			add(m[1] + style(bullet, "fg:magenta") + " " + renderInline(item))
+		case strings.HasPrefix(trimmed, ">"):
+			add(style("│", "fg:white") + " " + renderInline(strings.TrimSpace(strings.TrimLeft(trimmed, ">"))))
+		default:
+			add(renderInline(trimmed))
+		}
+	}
+	return lines
+}
+
+// renderInline style code spans, links and bold text of a line
+func renderInline(s string) string {
+	s = mdImage.ReplaceAllStringFunc(s, imageAlt)
+	s = mdHTML.ReplaceAllString(s, "")
+	var (
+		b    strings.Builder
+		last = 0
+	)
+	for _, loc := range mdInline.FindAllStringIndex(s, -1) {
+		b.WriteString(escapeMarkup(s[last:loc[0]]))
+		tok := s[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(tok, "`"):
+			b.WriteString(style(strings.Trim(tok, "`"), "fg:yellow"))
+		case strings.HasPrefix(tok, "["):
+			text, _, _ := strings.Cut(tok[1:], "](")
+			b.WriteString(style(text, "fg:blue,mod:underline"))
+		default:
+			b.WriteString(style(tok[2:len(tok)-2], "mod:bold"))
+		}
+		last = loc[1]
+	}
+	b.WriteString(escapeMarkup(s[last:]))
+	return b.String()
+}
+
+// plainInline strip inline markup of a line
+func plainInline(s string) string {
+	s = mdImage.ReplaceAllStringFunc(s, imageAlt)
+	s = mdHTML.ReplaceAllString(s, "")
+	return mdInline.ReplaceAllStringFunc(s, func(tok string) string {
+		switch {
+		case strings.HasPrefix(tok, "`"):
+			return strings.Trim(tok, "`")
+		case strings.HasPrefix(tok, "["):
+			text, _, _ := strings.Cut(tok[1:], "](")
+			return text
+		default:
+			return tok[2 : len(tok)-2]
+		}
+	})
+}
+
+// imageAlt replace image with its alt text, so image inside link is still clickable text
+func imageAlt(img string) string {
+	alt := mdImage.FindStringSubmatch(img)[1]
+	if len(strings.TrimSpace(alt)) == 0 {
+		return "image"
+	}
+	return alt
+}
+
+// style wrap text in termui markup, brackets in it are escaped
+func style(text, s string) string {
+	if len(strings.TrimSpace(text)) == 0 {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", escapeMarkup(text), s)
+}
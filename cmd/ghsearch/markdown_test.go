@@ -0,0 +1,101 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"headings", "# Title\ntext\n### Sub `x` ##", []string{
+			"[TITLE](fg:cyan,mod:bold)",
+			"text",
+			"",
+			"[Sub x](fg:cyan,mod:bold)",
+		}},
+		{"nested lists", "- a\n  - b\n    1. c\n2) d", []string{
+			"[•](fg:magenta) a",
+			"  [•](fg:magenta) b",
+			"    [1.](fg:magenta) c",
+			"[2)](fg:magenta) d",
+		}},
+		{"task lists", "- [ ] todo\n- [x] done\n* [X] **done**", []string{
+			"[☐](fg:magenta) todo",
+			"[☑](fg:magenta) done",
+			"[☑](fg:magenta) [done](mod:bold)",
+		}},
+		{"fenced code", "run:\n```go\nfmt.Println(a)\n\tb := x[0]\n```\n~~~\n# not heading\n~~~", []string{
+			"run:",
+			"",
+			"[    fmt.Println(a)](fg:yellow)",
+			"[        b := x⁅0⁆](fg:yellow)",
+			"",
+			"[    # not heading](fg:yellow)",
+			"",
+		}},
+		{"links and images", "see [docs](https://example.com) ![logo](logo.png) [![build](ci.svg)](https://ci) ![](x.png)", []string{
+			"see [docs](fg:blue,mod:underline) logo [build](fg:blue,mod:underline) image",
+		}},
+		{"blank lines", "\n\na\n\n\n\nb\n\n", []string{"a", "", "b", ""}},
+		{"brackets", "use `a[i]` or [[]byte](https://go.dev) <br/>\n> quote `x`\n---", []string{
+			"use [a⁅i⁆](fg:yellow) or ⁅⁅⁆byte⁆(https://go.dev) ",
+			"[│](fg:white) quote [x](fg:yellow)",
+			"[────────](fg:cyan)",
+		}},
+		{"tables", "| a | b |\n|---|:-:|\n| 1 | 2 |", []string{"| a | b |", "| 1 | 2 |"}},
+	}
+	for _, tt := range tests {
+		if got := renderMarkdown(tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStyle(t *testing.T) {
+	tests := []struct{ text, want string }{
+		{"go", "[go](fg:red)"},
+		{"  ", "  "},
+		{"a[0]", "[a⁅0⁆](fg:red)"},
+		{"]x", "[⁆x](fg:red)"},
+	}
+	for _, tt := range tests {
+		if got := style(tt.text, "fg:red"); got != tt.want {
+			t.Errorf("style(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdownBrackets(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"open bracket spans lines", "```python\nfoo = [\n    \"x\",\n]\n```\n- `[` opens a [list](https://x)", []string{
+			"    foo = [",
+			"        \"x\",",
+			"    ]",
+			"",
+			"• [ opens a list",
+		}},
+		{"markup like code", "```\nx[i](y)\na[i](b) [x](fg:red)\n```\ncall `a[i](b)` or **m[k]**", []string{
+			"    x[i](y)",
+			"    a[i](b) [x](fg:red)",
+			"",
+			"call a[i](b) or m[k]",
+		}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, line := range renderMarkdown(tt.src) {
+			got = append(got, visibleText(line))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/byebyebruce/ghsearch"
+)
+
+const (
+	maxPreviewLines = 5000
+	maxCachedBlobs  = 200
+	focusContext    = 5 // lines shown above the first match
+)
+
+// blobCache file contents by sha. blobs are immutable, so it lives as long as the process
+var blobCache = &fifoCache{m: make(map[string][]byte)}
+
+// fifoCache drop the oldest one when full
+type fifoCache struct {
+	mu    sync.Mutex
+	m     map[string][]byte
+	order []string
+}
+
+func (c *fifoCache) get(k string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.m[k]
+	return b, ok
+}
+
+func (c *fifoCache) put(k string, b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.m[k]; ok {
+		return
+	}
+	if len(c.order) >= maxCachedBlobs {
+		delete(c.m, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.m[k] = b
+	c.order = append(c.order, k)
+}
+
+// previewCode fetch file of code search item, highlight it and find the line of first match
+func previewCode(ctx context.Context, client *ghsearch.Client, item ghsearch.SearchCodeResultItems) ([]string, int, error) {
+	src, ok := blobCache.get(item.Sha)
+	if !ok {
+		var err error
+		if len(item.GitURL) > 0 {
+			src, err = client.GetBlob(ctx, item.GitURL)
+		} else {
+			src, err = client.GetFile(ctx, item.URL)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		blobCache.put(item.Sha, src)
+	}
+
+	text := strings.ReplaceAll(string(src), "\t", "    ")
+	lines := highlightCode(item.Name, text)
+	focus := firstMatchLine(text, item.TextMatches) - focusContext
+	if focus < 0 {
+		focus = 0
+	}
+	return lines, focus, nil
+}
+
+// firstMatchLine line index of the first matched fragment in content, 0 if not found
+func firstMatchLine(content string, matches []ghsearch.TextMatch) int {
+	for _, m := range matches {
+		if m.Property != "content" {
+			continue
+		}
+		idx := strings.Index(content, strings.ReplaceAll(m.Fragment, "\t", "    "))
+		if idx < 0 {
+			continue
+		}
+		// move to the matched text inside the fragment
+		for _, seg := range m.Segments() {
+			if seg.Match {
+				break
+			}
+			idx += len(seg.Text)
+		}
+		return strings.Count(content[:idx], "\n")
+	}
+	return 0
+}
+
+// highlightCode turn source into lines of termui styled text with line numbers
+func highlightCode(filename, src string) []string {
+	lexer := lexers.Match(filename)
+	if lexer == nil {
+		lexer = lexers.Analyse(src)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	var (
+		lines []string
+		cur   strings.Builder
+	)
+	flush := func() {
+		lines = append(lines, fmt.Sprintf("[%4d](fg:white) %s", len(lines)+1, cur.String()))
+		cur.Reset()
+	}
+	it, err := lexer.Tokenise(nil, src)
+	if err != nil {
+		for _, line := range strings.Split(src, "\n") {
+			cur.WriteString(escapeMarkup(line))
+			flush()
+		}
+		return lines
+	}
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		color := tokenColor(tok.Type)
+		parts := strings.Split(tok.Value, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				flush()
+				if len(lines) >= maxPreviewLines {
+					return lines
+				}
+			}
+			if len(color) == 0 || len(strings.TrimSpace(part)) == 0 {
+				cur.WriteString(escapeMarkup(part))
+			} else {
+				fmt.Fprintf(&cur, "[%s](fg:%s)", escapeMarkup(part), color)
+			}
+		}
+	}
+	if cur.Len() > 0 {
+		flush()
+	}
+	return lines
+}
+
+// markupEscaper swap brackets for lookalikes of the same width. termui takes any [ as start of
+// [text](style) markup, even in plain text, which drops or garbles text of ordinary code
+var markupEscaper = strings.NewReplacer("[", "⁅", "]", "⁆")
+
+// escapeMarkup make s safe to put into termui markup, inside or outside [text](style)
+func escapeMarkup(s string) string {
+	return markupEscaper.Replace(s)
+}
+
+func tokenColor(t chroma.TokenType) string {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return "blue"
+	case t.InCategory(chroma.Keyword):
+		return "magenta"
+	case t.InSubCategory(chroma.LiteralString):
+		return "green"
+	case t.InSubCategory(chroma.LiteralNumber):
+		return "cyan"
+	case t == chroma.NameFunction || t == chroma.NameBuiltin:
+		return "yellow"
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// visibleText text shown by termui for markup s, with escaped brackets restored
+func visibleText(s string) string {
+	var b strings.Builder
+	for _, c := range ui.ParseStyles(s, ui.StyleClear) {
+		b.WriteRune(c.Rune)
+	}
+	return strings.NewReplacer("⁅", "[", "⁆", "]").Replace(b.String())
+}
+
+func TestHighlightCode(t *testing.T) {
+	tests := []struct{ filename, src string }{
+		{"main.go", "func f() {\n\thandlers[i](w, r)\n\tm := map[string][]int{\"a\": {1}}\n}"},
+		{"a.py", "foo = [\n    \"x\",\n    1,\n]\nprint(foo[0](\"y\"))"},
+		{"a.json", "{\"a\": [\n  \"b\", [1, 2]\n]}"},
+		{"unknown", "[x](fg:red) [ ] ]["},
+	}
+	for _, tt := range tests {
+		lines := highlightCode(tt.filename, tt.src)
+		want := strings.Split(tt.src, "\n")
+		if len(lines) != len(want) {
+			t.Fatalf("%s: got %d lines, want %d", tt.filename, len(lines), len(want))
+		}
+		for i, line := range lines {
+			got := visibleText(line)
+			if num := got[:5]; strings.TrimSpace(num) == "" {
+				t.Errorf("%s: no line number in %q", tt.filename, got)
+			}
+			if got[5:] != want[i] {
+				t.Errorf("%s:%d: got %q, want %q", tt.filename, i+1, got[5:], want[i])
+			}
+		}
+	}
+}
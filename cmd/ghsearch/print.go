@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/output"
+)
+
+// printer print search results to stdout without terminal ui
+type printer struct {
+	format output.Format
+	text   string // template
+	limit  int
+}
+
+func printAll[T any](pr printer, it *ghsearch.Iterator[T], columns []output.Column[T]) error {
+	p, err := output.NewPrinter(os.Stdout, pr.format, pr.text, columns)
+	if err != nil {
+		return err
+	}
+	for n := 0; n < pr.limit && it.Next(); n++ {
+		if err := p.Print(it.Item()); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return p.Flush()
+}
+
+func printResults(ctx context.Context, pr printer, client *ghsearch.Client, kind string, q *ghsearch.Query, opt ghsearch.SearchOptions) error {
+	switch kind {
+	case "code":
+		return printAll(pr, client.SearchCodeIter(ctx, q, &opt), codeColumns)
+	case "issues":
+		return printAll(pr, client.SearchIssuesIter(ctx, q, &opt), issueColumns)
+	case "users":
+		return printAll(pr, client.SearchUsersIter(ctx, q, &opt), userColumns)
+	case "commits":
+		return printAll(pr, client.SearchCommitsIter(ctx, q, &opt), commitColumns)
+	case "topics":
+		return printAll(pr, client.SearchTopicsIter(ctx, q, &opt), topicColumns)
+	}
+	return printAll(pr, client.SearchRepoIter(ctx, q, &opt), repoColumns)
+}
+
+var repoColumns = []output.Column[ghsearch.SearchRepoResultItems]{
+	{Name: "name", Value: func(v ghsearch.SearchRepoResultItems) string { return v.FullName }},
+	{Name: "stars", Value: func(v ghsearch.SearchRepoResultItems) string { return strconv.Itoa(v.StargazersCount) }},
+	{Name: "forks", Value: func(v ghsearch.SearchRepoResultItems) string { return strconv.Itoa(v.ForksCount) }},
+	{Name: "language", Value: func(v ghsearch.SearchRepoResultItems) string { return v.Language }},
+	{Name: "pushed", Value: func(v ghsearch.SearchRepoResultItems) string { return v.PushedAt.Format(time.RFC3339) }},
+	{Name: "url", Value: func(v ghsearch.SearchRepoResultItems) string { return v.HTMLURL }},
+	{Name: "description", Value: func(v ghsearch.SearchRepoResultItems) string { return v.Description }},
+}
+
+var codeColumns = []output.Column[ghsearch.SearchCodeResultItems]{
+	{Name: "repo", Value: func(v ghsearch.SearchCodeResultItems) string { return v.Repository.FullName }},
+	{Name: "path", Value: func(v ghsearch.SearchCodeResultItems) string { return v.Path }},
+	{Name: "sha", Value: func(v ghsearch.SearchCodeResultItems) string { return v.Sha }},
+	{Name: "url", Value: func(v ghsearch.SearchCodeResultItems) string { return v.HTMLURL }},
+}
+
+var issueColumns = []output.Column[ghsearch.SearchIssueResultItems]{
+	{Name: "repo", Value: func(v ghsearch.SearchIssueResultItems) string { return v.RepositoryName() }},
+	{Name: "number", Value: func(v ghsearch.SearchIssueResultItems) string { return strconv.Itoa(v.Number) }},
+	{Name: "type", Value: func(v ghsearch.SearchIssueResultItems) string {
+		if v.IsPullRequest() {
+			return "pr"
+		}
+		return "issue"
+	}},
+	{Name: "state", Value: func(v ghsearch.SearchIssueResultItems) string { return v.State }},
+	{Name: "author", Value: func(v ghsearch.SearchIssueResultItems) string { return v.User.Login }},
+	{Name: "comments", Value: func(v ghsearch.SearchIssueResultItems) string { return strconv.Itoa(v.Comments) }},
+	{Name: "updated", Value: func(v ghsearch.SearchIssueResultItems) string { return v.UpdatedAt.Format(time.RFC3339) }},
+	{Name: "url", Value: func(v ghsearch.SearchIssueResultItems) string { return v.HTMLURL }},
+	{Name: "title", Value: func(v ghsearch.SearchIssueResultItems) string { return v.Title }},
+}
+
+var userColumns = []output.Column[ghsearch.SearchUserResultItems]{
+	{Name: "login", Value: func(v ghsearch.SearchUserResultItems) string { return v.Login }},
+	{Name: "type", Value: func(v ghsearch.SearchUserResultItems) string { return v.Type }},
+	{Name: "url", Value: func(v ghsearch.SearchUserResultItems) string { return v.HTMLURL }},
+}
+
+var commitColumns = []output.Column[ghsearch.SearchCommitResultItems]{
+	{Name: "repo", Value: func(v ghsearch.SearchCommitResultItems) string { return v.Repository.FullName }},
+	{Name: "sha", Value: func(v ghsearch.SearchCommitResultItems) string { return v.ShortSha() }},
+	{Name: "author", Value: func(v ghsearch.SearchCommitResultItems) string { return v.Commit.Author.Name }},
+	{Name: "date", Value: func(v ghsearch.SearchCommitResultItems) string { return v.Commit.Author.Date.Format(time.RFC3339) }},
+	{Name: "url", Value: func(v ghsearch.SearchCommitResultItems) string { return v.HTMLURL }},
+	{Name: "title", Value: func(v ghsearch.SearchCommitResultItems) string { return v.Title() }},
+}
+
+var topicColumns = []output.Column[ghsearch.SearchTopicResultItems]{
+	{Name: "name", Value: func(v ghsearch.SearchTopicResultItems) string { return v.Name }},
+	{Name: "display_name", Value: func(v ghsearch.SearchTopicResultItems) string { return v.DisplayName }},
+	{Name: "featured", Value: func(v ghsearch.SearchTopicResultItems) string { return strconv.FormatBool(v.Featured) }},
+	{Name: "curated", Value: func(v ghsearch.SearchTopicResultItems) string { return strconv.FormatBool(v.Curated) }},
+	{Name: "description", Value: func(v ghsearch.SearchTopicResultItems) string {
+		return strings.TrimSpace(v.ShortDescription)
+	}},
+}
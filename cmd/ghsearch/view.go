@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/util"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// view how to fetch and show one kind of search result
+type view[T any] struct {
+	name  string // list title
+	fetch func(ctx context.Context, page int) (*ghsearch.SearchResult[T], error)
+	row   func(item T) string // list row
+	desc  func(item T) string // detail pane
+	link  func(item T) string // opened in browser by enter
+
+	// optional, replace opening link on enter. e.g. drill down into another view by show
+	enter func(s *screen, item T) error
+
+	// optional, fetched lazily in background and appended to desc. cached by key
+	detail func(ctx context.Context, item T) (string, error)
+	key    func(item T) string
+
+	// optional, fetched lazily in background and shown in preview pane. cached by key.
+	// return styled lines and the line to scroll to
+	preview func(ctx context.Context, item T) ([]string, int, error)
+
+	// optional, toggled by r and shown in detail pane instead of desc, e.g. README.
+	// fetched lazily in background and cached by key
+	alt     func(ctx context.Context, item T) ([]string, error)
+	altName string
+}
+
+// previewState lines of preview pane and scroll offset
+type previewState struct {
+	lines  []string
+	offset int
+}
+
+// screen initialized terminal ui shared by nested views
+type screen struct {
+	ctx    context.Context // cancelled when leaving the terminal ui
+	events <-chan ui.Event
+}
+
+// browse init terminal ui and show search result
+func browse[T any](title string, perPage int, v view[T]) error {
+	if err := ui.Init(); err != nil {
+		return err
+	}
+	defer ui.Close()
+
+	defer ui.Clear()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	return show(&screen{ctx: ctx, events: ui.PollEvents()}, title, perPage, v)
+}
+
+// show search result page by page until q is pressed. next page is prefetched in background
+func show[T any](s *screen, title string, perPage int, v view[T]) error {
+	ctx, uiEvents := s.ctx, s.events
+	if perPage <= 0 {
+		perPage = ghsearch.DefaultPerPage
+	}
+	pages := newPageCache(ctx, v.fetch)
+	page := 1
+
+	// updates are run in ui loop, so background tasks never touch widgets directly
+	updates := make(chan func(), 16)
+	details := make(map[string]string)
+	previews := make(map[string]*previewState)
+	alts := make(map[string]*previewState)
+	showAlt := false
+	post := func(f func()) {
+		select {
+		case updates <- f:
+		case <-ctx.Done():
+		}
+	}
+	// lazyLines get cached lines of item, fetch them in background at the first time
+	lazyLines := func(cache map[string]*previewState, item T, fetch func(ctx context.Context, item T) ([]string, int, error)) *previewState {
+		k := v.key(item)
+		if ps, ok := cache[k]; ok {
+			return ps
+		}
+		ps := &previewState{lines: []string{"[loading...](fg:yellow)"}}
+		cache[k] = ps
+		go func() {
+			lines, focus, err := fetch(ctx, item)
+			if err != nil {
+				lines, focus = []string{fmt.Sprintf("[%s](fg:red)", err)}, 0
+			}
+			post(func() {
+				cache[k] = &previewState{lines: lines, offset: focus}
+			})
+		}()
+		return ps
+	}
+	fetchAlt := func(ctx context.Context, item T) ([]string, int, error) {
+		lines, err := v.alt(ctx, item)
+		return lines, 0, err
+	}
+
+LOOP:
+	for {
+		ui.Clear()
+
+		result, err := load(ctx, uiEvents, func(ctx context.Context) (*ghsearch.SearchResult[T], error) {
+			return pages.get(ctx, page)
+		})
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ret := result.Items
+		if len(ret) == 0 {
+			fmt.Println("no result")
+			time.Sleep(time.Second * 2)
+			return nil
+		}
+		totalPages := result.TotalPages(perPage)
+		if result.Links.Next > 0 {
+			pages.prefetch(result.Links.Next)
+		}
+
+		// title
+		titleP := widgets.NewParagraph()
+		titleP.Title = "Search"
+		titleP.TextStyle.Fg = ui.ColorWhite
+		titleP.Text = title
+
+		// input
+		input := widgets.NewParagraph()
+		help := "j/k: up/down, enter: open, q: quit, ctrl+n/p: next/previous page"
+		if v.preview != nil {
+			help += ", J/K: scroll preview"
+		}
+		if v.alt != nil {
+			help += fmt.Sprintf(", r: toggle %s", v.altName)
+			if v.preview == nil {
+				help += ", J/K: scroll " + v.altName
+			}
+		}
+		input.Title = fmt.Sprintf("%s | quota: %s", help, result.Rate)
+		input.TitleStyle = ui.NewStyle(ui.ColorCyan)
+		input.Border = false
+
+		// list
+		l := widgets.NewList()
+		l.Title = fmt.Sprintf("%s Page:%d/%d Total:%d", v.name, page, totalPages, result.TotalCount)
+		for i, item := range ret {
+			l.Rows = append(l.Rows, fmt.Sprintf("%2d %s", (page-1)*perPage+i+1, v.row(item)))
+		}
+		l.SelectedRowStyle = ui.NewStyle(ui.ColorWhite, ui.ColorCyan)
+		l.TextStyle = ui.NewStyle(ui.ColorWhite)
+		l.WrapText = false
+
+		// desc
+		p := widgets.NewParagraph()
+		p.Title = "Desc"
+		p.TextStyle.Fg = ui.ColorGreen
+		p.BorderStyle.Fg = ui.ColorCyan
+		p.WrapText = true
+		// preview
+		pv := widgets.NewParagraph()
+		pv.Title = "Preview"
+		pv.BorderStyle.Fg = ui.ColorCyan
+		pv.WrapText = false
+		showPreview := func(idx int) {
+			showLines(pv, "Preview", lazyLines(previews, ret[idx], v.preview))
+		}
+
+		showDesc := func(idx int) {
+			item := ret[idx]
+			if showAlt {
+				showLines(p, v.altName, lazyLines(alts, item, fetchAlt))
+				if v.preview != nil {
+					showPreview(idx)
+				}
+				return
+			}
+			p.Title = "Desc"
+			text := v.desc(item)
+			if v.detail != nil {
+				k := v.key(item)
+				d, ok := details[k]
+				if !ok {
+					// mark it loading, so it's fetched only once
+					d = "\n[loading...](fg:yellow)"
+					details[k] = d
+					go func() {
+						d, err := v.detail(ctx, item)
+						if err != nil {
+							d = fmt.Sprintf("\n[%s](fg:red)", err)
+						}
+						post(func() {
+							details[k] = d
+						})
+					}()
+				}
+				text += d
+			}
+			p.Text = text
+			if v.preview != nil {
+				showPreview(idx)
+			}
+		}
+
+		grid := ui.NewGrid()
+		termWidth, termHeight := ui.TerminalDimensions()
+		if v.preview != nil {
+			grid.Set(
+				ui.NewCol(0.4, l),
+				ui.NewCol(0.6,
+					ui.NewRow(0.35, p),
+					ui.NewRow(0.65, pv),
+				),
+			)
+		} else {
+			grid.Set(
+				ui.NewCol(0.4, l),
+				ui.NewCol(0.6, p),
+			)
+		}
+
+		onResize := func(w, h int) {
+			const titleOffset = 3
+			const inputOffset = 1
+			titleP.SetRect(0, 0, w, titleOffset)
+			grid.SetRect(0, titleOffset, w, h-inputOffset)
+			input.SetRect(0, h-inputOffset, w, h)
+		}
+
+		onResize(termWidth, termHeight)
+		showDesc(l.SelectedRow)
+		ui.Render(titleP, input, grid)
+
+		previousKey := ""
+		for {
+			var e ui.Event
+			select {
+			case e = <-uiEvents:
+			case f := <-updates:
+				f()
+				showDesc(l.SelectedRow)
+				ui.Render(titleP, input, grid)
+				continue
+			}
+			switch e.ID {
+			case "q":
+				return nil
+			case "<C-c>":
+				ui.Close()
+				os.Exit(0)
+			case "j", "<Down>":
+				l.ScrollDown()
+				showDesc(l.SelectedRow)
+			case "k", "<Up>":
+				l.ScrollUp()
+				showDesc(l.SelectedRow)
+			case "<C-d>":
+				l.ScrollHalfPageDown()
+				showDesc(l.SelectedRow)
+			case "<C-u>":
+				l.ScrollHalfPageUp()
+				showDesc(l.SelectedRow)
+			case "<C-f>":
+				l.ScrollPageDown()
+				showDesc(l.SelectedRow)
+			case "<C-b>":
+				l.ScrollPageUp()
+				showDesc(l.SelectedRow)
+			case "<C-n>":
+				if result.Links.Next > 0 && page < totalPages {
+					page++
+					continue LOOP
+				}
+			case "<C-p>":
+				if page > 1 {
+					page--
+					continue LOOP
+				}
+			case "g":
+				if previousKey == "g" {
+					l.ScrollTop()
+					showDesc(l.SelectedRow)
+				}
+			case "<Home>":
+				l.ScrollTop()
+				showDesc(l.SelectedRow)
+			case "G", "<End>":
+				l.ScrollBottom()
+				showDesc(l.SelectedRow)
+			case "J", "K":
+				if v.key == nil || len(ret) == 0 {
+					// nothing fetched to scroll
+					break
+				}
+				n := 3
+				if e.ID == "K" {
+					n = -n
+				}
+				cache := previews
+				if showAlt && v.preview == nil {
+					cache = alts
+				}
+				if ps, ok := cache[v.key(ret[l.SelectedRow])]; ok {
+					ps.offset += n
+					showDesc(l.SelectedRow)
+				}
+			case "r":
+				if v.alt != nil {
+					showAlt = !showAlt
+					showDesc(l.SelectedRow)
+				}
+			case "<Enter>":
+				if v.enter == nil {
+					util.OpenWebBrowser(v.link(ret[l.SelectedRow]))
+					break
+				}
+				if err := v.enter(s, ret[l.SelectedRow]); err != nil {
+					return err
+				}
+				ui.Clear()
+				onResize(ui.TerminalDimensions())
+			case "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				onResize(payload.Width, payload.Height)
+				showDesc(l.SelectedRow)
+				ui.Clear()
+			}
+
+			if previousKey == "g" {
+				previousKey = ""
+			} else {
+				previousKey = e.ID
+			}
+
+			ui.Render(titleP, input, grid)
+		}
+	}
+}
+
+// load run task and show loading bar. pressing q or ctrl+c cancels the task
+func load[T any](ctx context.Context, uiEvents <-chan ui.Event, task func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case e := <-uiEvents:
+				switch e.ID {
+				case "q", "<C-c>":
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return util.AsyncTaskAndShowLoadingBar("loading", func() (T, error) {
+		return task(ctx)
+	})
+}
+
+// pageCache fetch pages once and keep them, so going back and forth is instant
+type pageCache[T any] struct {
+	ctx   context.Context // pages are fetched with it, so they survive a cancelled load
+	fetch func(ctx context.Context, page int) (*ghsearch.SearchResult[T], error)
+
+	mu    sync.Mutex
+	pages map[int]*pendingPage[T]
+}
+
+type pendingPage[T any] struct {
+	done   chan struct{}
+	result *ghsearch.SearchResult[T]
+	err    error
+}
+
+func newPageCache[T any](ctx context.Context, fetch func(ctx context.Context, page int) (*ghsearch.SearchResult[T], error)) *pageCache[T] {
+	return &pageCache[T]{ctx: ctx, fetch: fetch, pages: make(map[int]*pendingPage[T])}
+}
+
+// prefetch start fetching page in background
+func (c *pageCache[T]) prefetch(page int) *pendingPage[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pages[page]; ok {
+		return p
+	}
+	p := &pendingPage[T]{done: make(chan struct{})}
+	c.pages[page] = p
+	go func() {
+		defer close(p.done)
+		p.result, p.err = c.fetch(c.ctx, page)
+		if p.err != nil {
+			// failed page will be fetched again next time
+			c.mu.Lock()
+			delete(c.pages, page)
+			c.mu.Unlock()
+		}
+	}()
+	return p
+}
+
+// get wait for page
+func (c *pageCache[T]) get(ctx context.Context, page int) (*ghsearch.SearchResult[T], error) {
+	p := c.prefetch(page)
+	select {
+	case <-p.done:
+		return p.result, p.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// showLines show lines of ps in w from its scroll offset
+func showLines(w *widgets.Paragraph, title string, ps *previewState) {
+	if ps.offset > len(ps.lines)-1 {
+		ps.offset = len(ps.lines) - 1
+	}
+	if ps.offset < 0 {
+		ps.offset = 0
+	}
+	end := ps.offset + w.Inner.Dy()
+	if end > len(ps.lines) || end <= ps.offset {
+		end = len(ps.lines)
+	}
+	w.Title = fmt.Sprintf("%s %d/%d", title, ps.offset+1, len(ps.lines))
+	w.Text = strings.Join(ps.lines[ps.offset:end], "\n")
+}
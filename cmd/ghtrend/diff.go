@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/archive"
+	"github.com/byebyebruce/ghsearch/output"
+	"github.com/byebyebruce/ghsearch/util"
+)
+
+var diffColumns = []output.Column[archive.DiffEntry]{
+	{Name: "change", Value: func(v archive.DiffEntry) string { return string(v.Change) }},
+	{Name: "rank", Value: func(v archive.DiffEntry) string { return rankString(v.Rank) }},
+	{Name: "prev_rank", Value: func(v archive.DiffEntry) string { return rankString(v.PrevRank) }},
+	{Name: "star_delta", Value: func(v archive.DiffEntry) string { return fmt.Sprintf("%+d", v.StarDelta) }},
+	{Name: "author", Value: func(v archive.DiffEntry) string { return v.Repo.Author }},
+	{Name: "name", Value: func(v archive.DiffEntry) string { return v.Repo.Name }},
+	{Name: "stars", Value: func(v archive.DiffEntry) string { return strconv.Itoa(v.Repo.Stars) }},
+	{Name: "link", Value: func(v archive.DiffEntry) string { return v.Repo.Link }},
+}
+
+func rankString(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return strconv.Itoa(rank)
+}
+
+// diffCmd ghtrend diff: compare trending list with the previous snapshot
+func diffCmd(args []string) error {
+	fs := flag.NewFlagSet("ghtrend diff", flag.ExitOnError)
+	var (
+		lang   = fs.String("lang", "go", "program language")
+		spoken = fs.String("spoken", "", "spoken language[zh/en/de/fr]. empty means any")
+		since  = fs.String("since", "daily", "date range[daily/weekly/monthly]")
+		host   = fs.String("host", "", "github host, e.g. ghe.corp. default env GITHUB_API_URL or GH_HOST or github.com")
+		db     = fs.String("db", defaultDBPath(), "snapshot file")
+		stored = fs.Bool("stored", false, "compare the latest two snapshots in file instead of fetching")
+		save   = fs.Bool("save", false, "save fetched list as a snapshot after comparing")
+		format = fs.String("format", "", "json/ndjson/csv/tsv/table/markdown/template. default table")
+		tmpl   = fs.String("template", "", "go text/template executed per entry, e.g. '{{.Change}} {{.Repo.Author}}/{{.Repo.Name}} {{.StarDelta}}'")
+	)
+	fs.Parse(args)
+	dateRange, err := ghsearch.ParseDateRange(*since)
+	if err != nil {
+		return err
+	}
+	spokenLang, err := ghsearch.ParseSpokenLanguage(*spoken)
+	if err != nil {
+		return err
+	}
+	if _, err := ghsearch.LanguageSlug(*lang); err != nil {
+		return err
+	}
+	outFormat, err := outputFormat(*format, *tmpl)
+	if err != nil {
+		return err
+	}
+	if len(*format) == 0 && len(*tmpl) == 0 {
+		outFormat = output.Table
+	}
+
+	store, err := openStore(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var cur *archive.Snapshot
+	if *stored {
+		cur, err = store.Previous(*lang, string(spokenLang), string(dateRange), time.Now())
+		if err != nil {
+			return err
+		}
+		if cur == nil {
+			return fmt.Errorf("no snapshot of %s %s in %s, run ghtrend snapshot first", *lang, dateRange, *db)
+		}
+	} else {
+		apiURL, webURL := util.GitHubURLs(*host)
+		client := ghsearch.NewClient(ghsearch.WithBaseURL(apiURL), ghsearch.WithWebURL(webURL))
+		cur, err = archive.Capture(context.Background(), client, *lang, string(spokenLang), string(dateRange))
+		var perr *ghsearch.ParseError
+		if errors.As(err, &perr) {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	prev, err := store.Previous(*lang, string(spokenLang), string(dateRange), cur.Time)
+	if err != nil {
+		return err
+	}
+	if prev == nil {
+		fmt.Fprintln(os.Stderr, "no previous snapshot, all are new")
+	}
+
+	p, err := output.NewPrinter(os.Stdout, outFormat, *tmpl, diffColumns)
+	if err != nil {
+		return err
+	}
+	for _, e := range archive.Diff(prev, cur) {
+		if err := p.Print(e); err != nil {
+			return err
+		}
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+
+	if *save && !*stored {
+		return store.Save(cur)
+	}
+	return nil
+}
+
+// badge short mark of change in terminal ui
+func badge(e archive.DiffEntry) string {
+	switch e.Change {
+	case archive.ChangeNew:
+		return "[new ](fg:green,mod:bold)"
+	case archive.ChangeRising:
+		return fmt.Sprintf("[%-4s](fg:green)", fmt.Sprintf("▲%d", e.PrevRank-e.Rank))
+	case archive.ChangeFalling:
+		return fmt.Sprintf("[%-4s](fg:red)", fmt.Sprintf("▼%d", e.Rank-e.PrevRank))
+	}
+	return "    "
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/byebyebruce/ghsearch"
+	"golang.org/x/sync/errgroup"
+)
+
+// trending lists of every language and date
+type trending struct {
+	langs []string
+	repos [][][]*ghsearch.Repository // [lang][date]
+	devs  [][][]*ghsearch.Developer  // [lang][date]
+}
+
+// splitLangs split comma separated languages. empty means any language
+func splitLangs(s string) ([]string, error) {
+	var langs []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			if _, err := ghsearch.LanguageSlug(v); err != nil {
+				return nil, err
+			}
+			langs = append(langs, v)
+		}
+	}
+	if len(langs) == 0 {
+		return []string{""}, nil
+	}
+	return langs, nil
+}
+
+// fetchAll fetch repositories and developers of all languages and dates, at most workers pages at the same time
+func fetchAll(ctx context.Context, client *ghsearch.Client, langs []string, spokenLang ghsearch.SpokenLanguage, workers int) (*trending, error) {
+	t := &trending{
+		langs: langs,
+		repos: make([][][]*ghsearch.Repository, len(langs)),
+		devs:  make([][][]*ghsearch.Developer, len(langs)),
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(workers)
+	for i, lang := range langs {
+		t.repos[i] = make([][]*ghsearch.Repository, len(dates))
+		t.devs[i] = make([][]*ghsearch.Developer, len(dates))
+		for j, date := range dates {
+			i, j, lang, date := i, j, lang, date
+			eg.Go(func() error {
+				f := ghsearch.TrendingFilter{Lang: lang, DateRange: date, SpokenLang: spokenLang}
+				repos, err := client.TrendingReposFiltered(ctx, f)
+				t.repos[i][j] = repos
+				var perr *ghsearch.ParseError
+				if errors.As(err, &perr) {
+					// show what is parsed
+					return nil
+				}
+				return err
+			})
+			eg.Go(func() error {
+				devs, err := client.TrendingDevelopersFiltered(ctx, ghsearch.TrendingFilter{Lang: lang, DateRange: date})
+				t.devs[i][j] = devs
+				return err
+			})
+		}
+	}
+	return t, eg.Wait()
+}
+
+// mergeRepos merge lists into one, deduplicated by Author/Name, most stars added first
+func mergeRepos(lists ...[]*ghsearch.Repository) []*ghsearch.Repository {
+	seen := make(map[string]bool)
+	var merged []*ghsearch.Repository
+	for _, list := range lists {
+		for _, r := range list {
+			k := r.Author + "/" + r.Name
+			if !seen[k] {
+				seen[k] = true
+				merged = append(merged, r)
+			}
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Add > merged[j].Add
+	})
+	return merged
+}
+
+// mergeDevelopers merge lists into one by taking turns in rank, deduplicated by Username
+func mergeDevelopers(lists ...[]*ghsearch.Developer) []*ghsearch.Developer {
+	seen := make(map[string]bool)
+	var merged []*ghsearch.Developer
+	for rank := 0; ; rank++ {
+		more := false
+		for _, list := range lists {
+			if rank >= len(list) {
+				continue
+			}
+			more = true
+			if d := list[rank]; !seen[d.Username] {
+				seen[d.Username] = true
+				merged = append(merged, d)
+			}
+		}
+		if !more {
+			return merged
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/byebyebruce/ghsearch"
+)
+
+func TestSplitLangs(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{"", []string{""}, false},
+		{" , ,", []string{""}, false},
+		{"go", []string{"go"}, false},
+		{"go, rust ,c#,", []string{"go", "rust", "c#"}, false},
+		{"go,rust/x", nil, true},
+		{"go,100%", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := splitLangs(tt.s)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitLangs(%q) = %q, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestMergeRepos(t *testing.T) {
+	repo := func(author, name string, add int) *ghsearch.Repository {
+		return &ghsearch.Repository{Author: author, Name: name, Add: add}
+	}
+	tests := []struct {
+		name  string
+		lists [][]*ghsearch.Repository
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"by add", [][]*ghsearch.Repository{
+			{repo("a", "x", 10), repo("b", "y", 30)},
+			{repo("c", "z", 20)},
+		}, []string{"b/y", "c/z", "a/x"}},
+		{"duplicates across languages", [][]*ghsearch.Repository{
+			{repo("a", "x", 10), repo("b", "y", 5)},
+			{repo("b", "y", 5), repo("a", "x", 10), repo("a", "w", 10)},
+			{},
+		}, []string{"a/x", "a/w", "b/y"}},
+		{"same name of other author", [][]*ghsearch.Repository{
+			{repo("a", "x", 1)},
+			{repo("b", "x", 2)},
+		}, []string{"b/x", "a/x"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range mergeRepos(tt.lists...) {
+			got = append(got, r.Author+"/"+r.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMergeDevelopers(t *testing.T) {
+	dev := func(username string) *ghsearch.Developer {
+		return &ghsearch.Developer{Username: username}
+	}
+	tests := []struct {
+		name  string
+		lists [][]*ghsearch.Developer
+		want  []string
+	}{
+		{"empty", [][]*ghsearch.Developer{{}, nil}, nil},
+		{"by turns", [][]*ghsearch.Developer{
+			{dev("a"), dev("b"), dev("c")},
+			{dev("x")},
+		}, []string{"a", "x", "b", "c"}},
+		{"duplicates across languages", [][]*ghsearch.Developer{
+			{dev("a"), dev("b")},
+			{dev("b"), dev("a"), dev("y")},
+		}, []string{"a", "b", "y"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, d := range mergeDevelopers(tt.lists...) {
+			got = append(got, d.Username)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/output"
+	"github.com/byebyebruce/ghsearch/util"
+)
+
+var languageColumns = []output.Column[ghsearch.LanguageOption]{
+	{Name: "slug", Value: func(v ghsearch.LanguageOption) string { return v.Slug }},
+	{Name: "name", Value: func(v ghsearch.LanguageOption) string { return v.Name }},
+}
+
+var spokenLanguageColumns = []output.Column[ghsearch.SpokenLanguageOption]{
+	{Name: "code", Value: func(v ghsearch.SpokenLanguageOption) string { return string(v.Code) }},
+	{Name: "name", Value: func(v ghsearch.SpokenLanguageOption) string { return v.Name }},
+}
+
+// languagesCmd ghtrend languages: list languages, or spoken languages with -spoken, offered by trending page
+func languagesCmd(args []string) error {
+	fs := flag.NewFlagSet("ghtrend languages", flag.ExitOnError)
+	var (
+		spoken = fs.Bool("spoken", false, "list spoken languages instead")
+		host   = fs.String("host", "", "github host, e.g. ghe.corp. default env GITHUB_API_URL or GH_HOST or github.com")
+		format = fs.String("format", "", "json/ndjson/csv/tsv/table/markdown/template. default table")
+		tmpl   = fs.String("template", "", "go text/template executed per language, e.g. '{{.Slug}}'")
+	)
+	fs.Parse(args)
+	outFormat, err := outputFormat(*format, *tmpl)
+	if err != nil {
+		return err
+	}
+	if len(*format) == 0 && len(*tmpl) == 0 {
+		outFormat = output.Table
+	}
+
+	apiURL, webURL := util.GitHubURLs(*host)
+	client := ghsearch.NewClient(ghsearch.WithBaseURL(apiURL), ghsearch.WithWebURL(webURL))
+	if *spoken {
+		langs, err := client.TrendingSpokenLanguages(context.Background())
+		if err != nil {
+			return err
+		}
+		return printOptions(outFormat, *tmpl, spokenLanguageColumns, langs)
+	}
+	langs, err := client.TrendingLanguages(context.Background())
+	if err != nil {
+		return err
+	}
+	return printOptions(outFormat, *tmpl, languageColumns, langs)
+}
+
+func printOptions[T any](format output.Format, tmpl string, columns []output.Column[T], opts []T) error {
+	p, err := output.NewPrinter(os.Stdout, format, tmpl, columns)
+	if err != nil {
+		return err
+	}
+	for _, v := range opts {
+		if err := p.Print(v); err != nil {
+			return err
+		}
+	}
+	return p.Flush()
+}
@@ -4,28 +4,155 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/archive"
+	"github.com/byebyebruce/ghsearch/output"
 	"github.com/byebyebruce/ghsearch/util"
 	ui "github.com/gizak/termui/v3"
 	"github.com/gizak/termui/v3/widgets"
-	"golang.org/x/sync/errgroup"
 )
 
 var (
-	dates      = []string{"daily", "weekly", "monthly"}
+	dates      = ghsearch.DateRanges
 	spokenLang = ""
 	lang       = ""
+	host       = ""
+	since      = "daily"
+	format     = ""
+	tmpl       = ""
+	db         = ""
+	workers    = 4
 )
 
-type Repos []*ghsearch.Repository
+// groups of tabs, each one has tabs of dates
+var groups = []string{"Repositories", "Developers"}
+
+// entry one row of list
+type entry struct {
+	row  string
+	desc string
+	link string
+}
+
+// repoEntries repositories marked by changes since prev, if prev is not nil
+func repoEntries(repos []*ghsearch.Repository, prev *archive.Snapshot) []entry {
+	diff := archive.Diff(prev, &archive.Snapshot{Repos: repos})
+	entries := make([]entry, 0, len(repos))
+	for i, v := range repos {
+		mark, change := "", ""
+		if prev != nil {
+			e := diff[i]
+			mark = badge(e) + " "
+			change = fmt.Sprintf("[Since %s: %s, rank %s → %d, %+d stars](fg:yellow)\n",
+				prev.Time.Local().Format("2006-01-02 15:04"), e.Change, rankString(e.PrevRank), e.Rank, e.StarDelta)
+		}
+		entries = append(entries, entry{
+			row: fmt.Sprintf("%2d %s⭐%-6d %s/%s", i+1, mark, v.Stars, v.Author, v.Name),
+			desc: fmt.Sprintf(`[Project: %s](fg:white,mod:bold)
+[Author: %s](fg:red)
+[Link: %s](fg:blue)
+[Language: %s](fg:blue)
+[Stars: %d, +%d %s](fg:yellow)
+[Built By: %s](fg:magenta)
+%sDesc: 
+    %s
+`, v.Name, v.Author, v.Link, v.Lang, v.Stars, v.Add, v.Period, strings.Join(logins(v.BuiltBy), ", "), change, v.Desc),
+			link: v.Link,
+		})
+	}
+	return entries
+}
+
+func developerEntries(devs []*ghsearch.Developer) []entry {
+	entries := make([]entry, 0, len(devs))
+	for i, v := range devs {
+		entries = append(entries, entry{
+			row: fmt.Sprintf("%2d %s (%s)", i+1, v.Name, v.Username),
+			desc: fmt.Sprintf(`[Name: %s](fg:white,mod:bold)
+[Username: %s](fg:red)
+[Link: %s](fg:blue)
+[Popular Repo: %s](fg:yellow)
+Desc: 
+    %s
+`, v.Name, v.Username, v.Link, v.PopularRepo, v.Desc),
+			link: v.Link,
+		})
+	}
+	return entries
+}
 
 func main() {
+	if len(os.Args) > 1 {
+		cmds := map[string]func(args []string) error{
+			"snapshot":  snapshotCmd,
+			"diff":      diffCmd,
+			"languages": languagesCmd,
+		}
+		if cmd, ok := cmds[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flag.StringVar(&spokenLang, "spoken", "", "spoken language[zh/en/de/fr]. empty means any")
-	flag.StringVar(&lang, "lang", "go", "program languages:go,rust,c,c++,java,c#,js")
+	flag.StringVar(&lang, "lang", "go", "program languages split by comma, e.g. go,rust,typescript. empty means any")
+	flag.StringVar(&host, "host", "", "github host, e.g. ghe.corp. default env GITHUB_API_URL or GH_HOST or github.com")
+	flag.StringVar(&since, "since", "daily", "date range[daily/weekly/monthly]")
+	flag.StringVar(&format, "format", "", "print repositories of -since without terminal ui: json/ndjson/csv/tsv/table/markdown/template. default tsv when stdout is not a terminal")
+	flag.StringVar(&tmpl, "template", "", "go text/template executed per repository, e.g. '{{.Author}}/{{.Name}} +{{.Add}}'")
+	flag.StringVar(&db, "db", defaultDBPath(), "snapshot file, repositories are marked by changes since the latest snapshot")
+	flag.IntVar(&workers, "workers", 4, "max trending pages fetched at the same time")
 	flag.Parse()
 
+	sinceRange, err := ghsearch.ParseDateRange(since)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	sinceIdx := 0
+	for i, v := range dates {
+		if v == sinceRange {
+			sinceIdx = i
+		}
+	}
+	spoken, err := ghsearch.ParseSpokenLanguage(spokenLang)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	langs, err := splitLangs(lang)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if workers <= 0 {
+		fmt.Println("workers should be positive")
+		os.Exit(1)
+	}
+
+	apiURL, webURL := util.GitHubURLs(host)
+	client := ghsearch.NewClient(ghsearch.WithBaseURL(apiURL), ghsearch.WithWebURL(webURL))
+
+	if len(format) > 0 || len(tmpl) > 0 || !output.IsTerminal(os.Stdout) {
+		f, err := outputFormat(format, tmpl)
+		if err == nil {
+			err = printTrending(context.Background(), client, f, tmpl, langs, sinceRange, spoken)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := ui.Init(); err != nil {
 		fmt.Println("failed to initialize termui", err)
 		return
@@ -34,34 +161,89 @@ func main() {
 	defer ui.Close()
 
 	// get data
-	ret, err := util.AsyncTaskAndShowLoadingBar("loading", func() ([]Repos, error) {
-		reps := make([]Repos, len(dates))
-		eg, _ := errgroup.WithContext(context.Background())
-		for i, v := range dates {
-			idx := i
-			date := v
-			eg.Go(func() error {
-				time.Sleep(time.Millisecond * time.Duration(idx*50)) // 避免github api限制
-				ret, err := ghsearch.TrendingRepos(lang, date, spokenLang)
-				if err != nil {
-					return err
-				}
-				reps[idx] = ret
-				return nil
-			})
-		}
-		return reps, eg.Wait()
+	t, err := util.AsyncTaskAndShowLoadingBar("loading", func() (*trending, error) {
+		return fetchAll(context.Background(), client, langs, spoken, workers)
 	})
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
+	// previous snapshots for marking changes, optional. prevs[lang][date]
+	prevs := make([][]*archive.Snapshot, len(langs))
+	for i := range prevs {
+		prevs[i] = make([]*archive.Snapshot, len(dates))
+	}
+	if _, err := os.Stat(db); err == nil {
+		if store, err := archive.Open(db); err == nil {
+			for i, lang := range langs {
+				for j, date := range dates {
+					prevs[i][j], _ = store.Previous(lang, string(spoken), string(date), time.Now())
+				}
+			}
+			store.Close()
+		}
+	}
+
+	// ret[group][lang][date], the last lang is all merged if there are more than one
+	langNames := make([]string, 0, len(langs)+1)
+	for _, v := range langs {
+		if len(v) == 0 {
+			v = "any"
+		}
+		langNames = append(langNames, v)
+	}
+	ret := make([][][][]entry, len(groups))
+	for g := range groups {
+		ret[g] = make([][][]entry, len(langs))
+		for i := range langs {
+			ret[g][i] = make([][]entry, len(dates))
+			for j := range dates {
+				if g == 0 {
+					ret[g][i][j] = repoEntries(t.repos[i][j], prevs[i][j])
+				} else {
+					ret[g][i][j] = developerEntries(t.devs[i][j])
+				}
+			}
+		}
+	}
+	if len(langs) > 1 {
+		langNames = append(langNames, "all")
+		all := [2][][]entry{make([][]entry, len(dates)), make([][]entry, len(dates))}
+		for j := range dates {
+			var repos [][]*ghsearch.Repository
+			var devs [][]*ghsearch.Developer
+			for i := range langs {
+				repos = append(repos, t.repos[i][j])
+				devs = append(devs, t.devs[i][j])
+			}
+			all[0][j] = repoEntries(mergeRepos(repos...), nil)
+			all[1][j] = developerEntries(mergeDevelopers(devs...))
+		}
+		ret[0] = append(ret[0], all[0])
+		ret[1] = append(ret[1], all[1])
+	}
+
 	// tab
-	tabpane := widgets.NewTabPane(dates...)
+	groupPane := widgets.NewTabPane(groups...)
+	groupPane.Title = "Trending"
+	groupPane.Border = true
+	groupPane.BorderStyle.Fg = ui.ColorYellow
+
+	langPane := widgets.NewTabPane(langNames...)
+	langPane.Title = "Language"
+	langPane.Border = true
+	langPane.BorderStyle.Fg = ui.ColorYellow
+
+	dateNames := make([]string, 0, len(dates))
+	for _, v := range dates {
+		dateNames = append(dateNames, string(v))
+	}
+	tabpane := widgets.NewTabPane(dateNames...)
 	tabpane.Title = "Date"
 	tabpane.Border = true
 	tabpane.BorderStyle.Fg = ui.ColorYellow
+	tabpane.ActiveTabIndex = sinceIdx
 
 	// list
 	l := widgets.NewList()
@@ -78,7 +260,7 @@ func main() {
 
 	// help
 	help := widgets.NewParagraph()
-	help.Title = fmt.Sprintf("j↓/k↑: down/up, h←/l→: tab left/regiht, enter: open, q: quit")
+	help.Title = fmt.Sprintf("j↓/k↑: down/up, h←/l→: tab left/regiht, H/L: language, tab: repositories/developers, enter: open, q: quit")
 	help.TitleStyle = ui.NewStyle(ui.ColorCyan)
 	help.Border = false
 
@@ -89,43 +271,44 @@ func main() {
 		ui.NewCol(0.6, p),
 	)
 
-	showList := func(repos Repos) {
-		l.Rows = l.Rows[:0]
-		for i, v := range repos {
-			l.Rows = append(l.Rows, fmt.Sprintf("%2d ⭐%-6d %s/%s", i+1, v.Stars, v.Author, v.Name))
+	var currentList []entry
+	showDesc := func() {
+		if len(currentList) == 0 {
+			p.Text = "no result"
+			return
 		}
+		p.Text = currentList[l.SelectedRow].desc
 	}
-
-	showDesc := func(current *ghsearch.Repository) {
-		p.Text = fmt.Sprintf(`[Project: %s](fg:white,mod:bold)
-[Author: %s](fg:red)
-[Link: %s](fg:blue)
-Desc: 
-    %s
-`, current.Name, current.Author, current.Link, current.Desc)
-	}
-
-	currentList := ret[0]
-	onTab := func(index int) {
-		currentList = ret[index]
-		showList(currentList)
-		showDesc(currentList[l.SelectedRow])
+	onTab := func() {
+		currentList = ret[groupPane.ActiveTabIndex][langPane.ActiveTabIndex][tabpane.ActiveTabIndex]
+		l.Rows = l.Rows[:0]
+		for _, v := range currentList {
+			l.Rows = append(l.Rows, v.row)
+		}
+		if l.SelectedRow < 0 || l.SelectedRow >= len(l.Rows) {
+			l.SelectedRow = 0
+		}
+		showDesc()
 	}
 	onResize := func(w, h int) {
 		ui.Clear()
 		const tabOffset = 3
 		const helpOffset = 1
-		tabpane.SetRect(0, 0, w, tabOffset)
+		const groupWidth = 30
+		const dateWidth = 30
+		groupPane.SetRect(0, 0, groupWidth, tabOffset)
+		langPane.SetRect(groupWidth, 0, w-dateWidth, tabOffset)
+		tabpane.SetRect(w-dateWidth, 0, w, tabOffset)
 		grid.SetRect(0, tabOffset, w, h-helpOffset)
 		help.SetRect(0, h-helpOffset, w, h)
 
 	}
 	render := func() {
-		ui.Render(tabpane, help, grid)
+		ui.Render(groupPane, langPane, tabpane, help, grid)
 	}
 
 	termWidth, termHeight := ui.TerminalDimensions()
-	onTab(tabpane.ActiveTabIndex)
+	onTab()
 	onResize(termWidth, termHeight)
 	render()
 
@@ -138,16 +321,25 @@ Desc:
 			return
 		case "j", "<Down>":
 			l.ScrollDown()
-			showDesc(currentList[l.SelectedRow])
+			showDesc()
 		case "k", "<Up>":
 			l.ScrollUp()
-			showDesc(currentList[l.SelectedRow])
+			showDesc()
 		case "h", "<Left>":
 			tabpane.FocusLeft()
-			onTab(tabpane.ActiveTabIndex)
+			onTab()
 		case "l", "<Right>":
 			tabpane.FocusRight()
-			onTab(tabpane.ActiveTabIndex)
+			onTab()
+		case "H":
+			langPane.FocusLeft()
+			onTab()
+		case "L":
+			langPane.FocusRight()
+			onTab()
+		case "<Tab>":
+			groupPane.ActiveTabIndex = (groupPane.ActiveTabIndex + 1) % len(groups)
+			onTab()
 		case "<C-d>":
 			l.ScrollHalfPageDown()
 		case "<C-u>":
@@ -165,8 +357,9 @@ Desc:
 		case "G", "<End>":
 			l.ScrollBottom()
 		case "<Enter>":
-			link := currentList[l.SelectedRow].Link
-			util.OpenWebBrowser(link)
+			if len(currentList) > 0 {
+				util.OpenWebBrowser(currentList[l.SelectedRow].link)
+			}
 		case "<Resize>":
 			payload := e.Payload.(ui.Resize)
 			onResize(payload.Width, payload.Height)
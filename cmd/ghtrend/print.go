@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/output"
+)
+
+var repoColumns = []output.Column[*ghsearch.Repository]{
+	{Name: "author", Value: func(v *ghsearch.Repository) string { return v.Author }},
+	{Name: "name", Value: func(v *ghsearch.Repository) string { return v.Name }},
+	{Name: "lang", Value: func(v *ghsearch.Repository) string { return v.Lang }},
+	{Name: "stars", Value: func(v *ghsearch.Repository) string { return strconv.Itoa(v.Stars) }},
+	{Name: "forks", Value: func(v *ghsearch.Repository) string { return strconv.Itoa(v.Forks) }},
+	{Name: "add", Value: func(v *ghsearch.Repository) string { return strconv.Itoa(v.Add) }},
+	{Name: "link", Value: func(v *ghsearch.Repository) string { return v.Link }},
+	{Name: "period", Value: func(v *ghsearch.Repository) string { return string(v.Period) }},
+	{Name: "built_by", Value: func(v *ghsearch.Repository) string { return strings.Join(logins(v.BuiltBy), " ") }},
+	{Name: "desc", Value: func(v *ghsearch.Repository) string { return v.Desc }},
+}
+
+// printTrending print trending repositories of langs in since to stdout without terminal ui
+func printTrending(ctx context.Context, client *ghsearch.Client, format output.Format, tmpl string, langs []string, since ghsearch.DateRange, spokenLang ghsearch.SpokenLanguage) error {
+	p, err := output.NewPrinter(os.Stdout, format, tmpl, repoColumns)
+	if err != nil {
+		return err
+	}
+	for _, lang := range langs {
+		repos, err := client.TrendingReposFiltered(ctx, ghsearch.TrendingFilter{Lang: lang, DateRange: since, SpokenLang: spokenLang})
+		var perr *ghsearch.ParseError
+		if errors.As(err, &perr) {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		} else if err != nil {
+			return err
+		}
+		for _, r := range repos {
+			if err := p.Print(r); err != nil {
+				return err
+			}
+		}
+	}
+	return p.Flush()
+}
+
+// outputFormat format by flags, tsv if both are empty
+func outputFormat(format, tmpl string) (output.Format, error) {
+	if len(format) > 0 {
+		return output.ParseFormat(format)
+	}
+	if len(tmpl) > 0 {
+		return output.Template, nil
+	}
+	return output.TSV, nil
+}
+
+func logins(cs []ghsearch.Contributor) []string {
+	var ls []string
+	for _, c := range cs {
+		ls = append(ls, c.Login)
+	}
+	return ls
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byebyebruce/ghsearch"
+	"github.com/byebyebruce/ghsearch/archive"
+	"github.com/byebyebruce/ghsearch/output"
+	"github.com/byebyebruce/ghsearch/util"
+)
+
+// snapshotRow one repository of a snapshot
+type snapshotRow struct {
+	Time       time.Time            `json:"time"`
+	Lang       string               `json:"lang"`
+	SpokenLang string               `json:"spoken_lang"`
+	Since      string               `json:"since"`
+	Rank       int                  `json:"rank"`
+	Repo       *ghsearch.Repository `json:"repo"`
+}
+
+var snapshotColumns = []output.Column[snapshotRow]{
+	{Name: "time", Value: func(v snapshotRow) string { return v.Time.Local().Format("2006-01-02 15:04") }},
+	{Name: "lang", Value: func(v snapshotRow) string { return v.Lang }},
+	{Name: "since", Value: func(v snapshotRow) string { return v.Since }},
+	{Name: "rank", Value: func(v snapshotRow) string { return strconv.Itoa(v.Rank) }},
+	{Name: "author", Value: func(v snapshotRow) string { return v.Repo.Author }},
+	{Name: "name", Value: func(v snapshotRow) string { return v.Repo.Name }},
+	{Name: "stars", Value: func(v snapshotRow) string { return strconv.Itoa(v.Repo.Stars) }},
+	{Name: "add", Value: func(v snapshotRow) string { return strconv.Itoa(v.Repo.Add) }},
+	{Name: "link", Value: func(v snapshotRow) string { return v.Repo.Link }},
+}
+
+// defaultDBPath ~/.ghtrend/trending.db
+func defaultDBPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "trending.db"
+	}
+	return filepath.Join(home, ".ghtrend", "trending.db")
+}
+
+// openStore open snapshot file, create its dir if not exist
+func openStore(path string) (*archive.Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return archive.Open(path)
+}
+
+// snapshotCmd ghtrend snapshot: save trending lists, or list saved ones with -list
+func snapshotCmd(args []string) error {
+	fs := flag.NewFlagSet("ghtrend snapshot", flag.ExitOnError)
+	var (
+		lang    = fs.String("lang", "go", "program languages to save, split by comma. with -list, only the language")
+		spoken  = fs.String("spoken", "", "spoken language[zh/en/de/fr]. empty means any")
+		sinces  = fs.String("since", "daily,weekly,monthly", "date ranges to save, split by comma. with -list, only the date range")
+		host    = fs.String("host", "", "github host, e.g. ghe.corp. default env GITHUB_API_URL or GH_HOST or github.com")
+		db      = fs.String("db", defaultDBPath(), "snapshot file")
+		list    = fs.Bool("list", false, "list saved snapshots instead of saving")
+		date    = fs.String("date", "", "with -list, only snapshots of the date, e.g. 2024-05-01")
+		format  = fs.String("format", "", "with -list, json/ndjson/csv/tsv/table/markdown/template. default table")
+		tmpl    = fs.String("template", "", "with -list, go text/template executed per repository, e.g. '{{.Rank}} {{.Repo.Author}}/{{.Repo.Name}}'")
+		changed = make(map[string]bool)
+	)
+	fs.Parse(args)
+	fs.Visit(func(f *flag.Flag) {
+		changed[f.Name] = true
+	})
+	spokenLang, err := ghsearch.ParseSpokenLanguage(*spoken)
+	if err != nil {
+		return err
+	}
+
+	langs, err := splitLangs(*lang)
+	if err != nil {
+		return err
+	}
+	var dateRanges []ghsearch.DateRange
+	for _, v := range strings.Split(*sinces, ",") {
+		since, err := ghsearch.ParseDateRange(v)
+		if err != nil {
+			return err
+		}
+		dateRanges = append(dateRanges, since)
+	}
+
+	store, err := openStore(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if *list {
+		f := archive.Filter{SpokenLang: string(spokenLang)}
+		if changed["lang"] {
+			f.Lang = *lang
+		}
+		if changed["since"] {
+			f.Since = string(dateRanges[0])
+		}
+		if len(*date) > 0 {
+			day, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+			if err != nil {
+				return fmt.Errorf("bad date %s, should be like 2024-05-01", *date)
+			}
+			f.From, f.To = day, day.AddDate(0, 0, 1)
+		}
+		outFormat, err := outputFormat(*format, *tmpl)
+		if err != nil {
+			return err
+		}
+		if len(*format) == 0 && len(*tmpl) == 0 {
+			outFormat = output.Table
+		}
+		return listSnapshots(store, f, outFormat, *tmpl)
+	}
+
+	apiURL, webURL := util.GitHubURLs(*host)
+	client := ghsearch.NewClient(ghsearch.WithBaseURL(apiURL), ghsearch.WithWebURL(webURL))
+	for _, lang := range langs {
+		for _, since := range dateRanges {
+			snap, err := archive.Capture(context.Background(), client, lang, string(spokenLang), string(since))
+			var perr *ghsearch.ParseError
+			if errors.As(err, &perr) {
+				fmt.Fprintln(os.Stderr, "warning:", err)
+			} else if err != nil {
+				return err
+			}
+			if err := store.Save(snap); err != nil {
+				return err
+			}
+			fmt.Printf("saved %s %s: %d repositories\n", lang, since, len(snap.Repos))
+		}
+	}
+	return nil
+}
+
+func listSnapshots(store *archive.Store, f archive.Filter, format output.Format, tmpl string) error {
+	snaps, err := store.Query(f)
+	if err != nil {
+		return err
+	}
+	p, err := output.NewPrinter(os.Stdout, format, tmpl, snapshotColumns)
+	if err != nil {
+		return err
+	}
+	for _, snap := range snaps {
+		for i, r := range snap.Repos {
+			row := snapshotRow{Time: snap.Time, Lang: snap.Lang, SpokenLang: snap.SpokenLang, Since: snap.Since, Rank: i + 1, Repo: r}
+			if err := p.Print(row); err != nil {
+				return err
+			}
+		}
+	}
+	return p.Flush()
+}
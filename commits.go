@@ -0,0 +1,91 @@
+package ghsearch
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// MinimalRepository repository brief in search results
+type MinimalRepository struct {
+	ID          int        `json:"id"`
+	NodeID      string     `json:"node_id"`
+	Name        string     `json:"name"`
+	FullName    string     `json:"full_name"`
+	Owner       SimpleUser `json:"owner"`
+	Private     bool       `json:"private"`
+	HTMLURL     string     `json:"html_url"`
+	Description string     `json:"description"`
+	Fork        bool       `json:"fork"`
+	URL         string     `json:"url"`
+}
+
+// GitActor git author or committer
+type GitActor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// SearchCommitResult one page of commit search result
+type SearchCommitResult = SearchResult[SearchCommitResultItems]
+
+// SearchCommitResultItems commit
+type SearchCommitResultItems struct {
+	URL         string `json:"url"`
+	Sha         string `json:"sha"`
+	NodeID      string `json:"node_id"`
+	HTMLURL     string `json:"html_url"`
+	CommentsURL string `json:"comments_url"`
+	Commit      struct {
+		URL       string   `json:"url"`
+		Author    GitActor `json:"author"`
+		Committer GitActor `json:"committer"`
+		Message   string   `json:"message"`
+		Tree      struct {
+			URL string `json:"url"`
+			Sha string `json:"sha"`
+		} `json:"tree"`
+		CommentCount int `json:"comment_count"`
+	} `json:"commit"`
+	Author    *SimpleUser `json:"author"` // nil if git author is not a github user
+	Committer *SimpleUser `json:"committer"`
+	Parents   []struct {
+		URL     string `json:"url"`
+		HTMLURL string `json:"html_url"`
+		Sha     string `json:"sha"`
+	} `json:"parents"`
+	Repository MinimalRepository `json:"repository"`
+	Score      float32           `json:"score"`
+}
+
+// ShortSha first 7 characters of sha
+func (c *SearchCommitResultItems) ShortSha() string {
+	if len(c.Sha) > 7 {
+		return c.Sha[:7]
+	}
+	return c.Sha
+}
+
+// Title first line of commit message
+func (c *SearchCommitResultItems) Title() string {
+	title, _, _ := strings.Cut(c.Commit.Message, "\n")
+	return title
+}
+
+// SearchCommits search commits by keywords, which may contain qualifiers like repo:golang/go author:rsc.
+// it's the first page of SearchResult in server's order, see SearchCommitsQuery and SearchCommitsIter for options and more pages
+func (c *Client) SearchCommits(ctx context.Context, keywords ...string) ([]SearchCommitResultItems, error) {
+	return searchKeywords[SearchCommitResultItems](ctx, c, "/search/commits", commitSorts, keywords)
+}
+
+// SearchCommitsQuery search commits by structured query. items are in server's order
+// https://docs.github.com/en/rest/search/search#search-commits
+func (c *Client) SearchCommitsQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchCommitResult, error) {
+	return search[SearchCommitResultItems](ctx, c, "/search/commits", q, opt, commitSorts)
+}
+
+// SearchCommitsIter iterate all commits matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchCommitsIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchCommitResultItems] {
+	return searchIter[SearchCommitResultItems](ctx, c, "/search/commits", q, opt, commitSorts)
+}
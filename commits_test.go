@@ -0,0 +1,28 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchCommits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/commits" || r.URL.Query().Get("q") != "fix repo:golang/go merge:false" || r.URL.Query().Get("sort") != "author-date" {
+			t.Errorf("request %s", r.URL)
+		}
+		w.Write([]byte(`{"total_count":1,"items":[{"sha":"0123456789abcdef","html_url":"https://github.com/golang/go/commit/0123456789abcdef","commit":{"message":"fix bug\n\ndetails","author":{"name":"rsc","date":"2024-01-02T03:04:05Z"}},"author":null,"repository":{"full_name":"golang/go"}}]}`))
+	}))
+	defer srv.Close()
+
+	q := NewQuery("fix").Repo("golang/go").Merge(false)
+	r, err := NewClient(WithBaseURL(srv.URL)).SearchCommitsQuery(context.Background(), q, &SearchOptions{Sort: SortAuthorDate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Items[0]
+	if c.ShortSha() != "0123456" || c.Title() != "fix bug" || c.Author != nil || c.Repository.FullName != "golang/go" || c.Commit.Author.Date.Year() != 2024 {
+		t.Fatalf("%+v", c)
+	}
+}
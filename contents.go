@@ -0,0 +1,41 @@
+package ghsearch
+
+import (
+	"context"
+	"net/url"
+)
+
+// rawMediaType media type to get raw file content
+const rawMediaType = "application/vnd.github.raw+json"
+
+// GetBlob get raw content of a git blob by its api url, e.g. SearchCodeResultItems.GitURL.
+// blob is immutable, so it can be cached by sha
+// https://docs.github.com/en/rest/git/blobs#get-a-blob
+func (c *Client) GetBlob(ctx context.Context, gitURL string) ([]byte, error) {
+	var b []byte
+	if _, err := c.getAccept(ctx, gitURL, rawMediaType, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetFile get raw content of a file by its contents api url, e.g. SearchCodeResultItems.URL
+// https://docs.github.com/en/rest/repos/contents#get-repository-content
+func (c *Client) GetFile(ctx context.Context, contentsURL string) ([]byte, error) {
+	var b []byte
+	if _, err := c.getAccept(ctx, contentsURL, rawMediaType, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetReadme get raw content of the preferred README of a repository
+// https://docs.github.com/en/rest/repos/contents#get-a-repository-readme
+func (c *Client) GetReadme(ctx context.Context, owner, repo string) ([]byte, error) {
+	var b []byte
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/readme"
+	if _, err := c.getAccept(ctx, path, rawMediaType, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
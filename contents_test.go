@@ -0,0 +1,50 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetBlob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != rawMediaType || r.Header.Get("Authorization") != "Bearer abc" {
+			t.Errorf("header %v", r.Header)
+		}
+		w.Write([]byte("package main\n"))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL), WithToken("abc"))
+	b, err := c.GetBlob(ctx, srv.URL+"/repositories/1/git/blobs/abc")
+	if err != nil || string(b) != "package main\n" {
+		t.Fatalf("%q %v", b, err)
+	}
+	if _, err := c.GetFile(ctx, "https://evil.example.com/x"); err == nil {
+		t.Fatal("url outside base url should be rejected")
+	}
+}
+
+func TestGetReadme(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/foo/bar/readme" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		w.Write([]byte("# bar\n"))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+	b, err := c.GetReadme(ctx, "foo", "bar")
+	if err != nil || string(b) != "# bar\n" {
+		t.Fatalf("%q %v", b, err)
+	}
+	if _, err := c.GetReadme(ctx, "foo", "none"); !IsNotFound(err) {
+		t.Fatalf("want not found, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package ghsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError error response of github api
+// https://docs.github.com/en/rest/using-the-rest-api/troubleshooting-the-rest-api
+type APIError struct {
+	StatusCode       int           `json:"-"`
+	Message          string        `json:"message"`
+	DocumentationURL string        `json:"documentation_url"`
+	Errors           []ErrorDetail `json:"errors"`
+	Rate             RateLimit     `json:"-"`
+
+	rateLimited bool
+}
+
+// ErrorDetail one item of errors in api error response
+type ErrorDetail struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// UnmarshalJSON some apis return errors as plain strings
+func (d *ErrorDetail) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		d.Message = s
+		return nil
+	}
+	type detail ErrorDetail
+	return json.Unmarshal(b, (*detail)(d))
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if len(msg) == 0 {
+		msg = http.StatusText(e.StatusCode)
+	}
+	s := fmt.Sprintf("github: %d %s", e.StatusCode, msg)
+	var details []string
+	for _, d := range e.Errors {
+		if len(d.Message) > 0 {
+			details = append(details, d.Message)
+		} else if len(d.Field) > 0 {
+			details = append(details, d.Field+" "+d.Code)
+		}
+	}
+	if len(details) > 0 {
+		s += ": " + strings.Join(details, "; ")
+	}
+	return s
+}
+
+// newAPIError decode error response body. body which is not json is kept as message
+func newAPIError(status int, rate RateLimit, body []byte) *APIError {
+	e := &APIError{}
+	if err := json.Unmarshal(body, e); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	e.StatusCode = status
+	e.Rate = rate
+	e.rateLimited, _ = rateLimited(status, rate, body)
+	return e
+}
+
+// IsRateLimited err is caused by primary or secondary rate limit
+func IsRateLimited(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.rateLimited
+}
+
+// IsUnauthorized err is caused by bad or missing credentials
+func IsUnauthorized(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusUnauthorized
+}
+
+// IsForbidden err is caused by lacking permission, not rate limit
+func IsForbidden(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusForbidden && !e.rateLimited
+}
+
+// IsNotFound err is caused by missing resource
+func IsNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// IsValidationFailed err is caused by invalid request, e.g. bad search query
+func IsValidationFailed(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsServerError err is caused by github server
+func IsServerError(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,32 @@
+package ghsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"Search","field":"q","code":"invalid","message":"bad query"},"plain"],"documentation_url":"https://docs.github.com/v3/search/"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(WithBaseURL(srv.URL)).SearchRepoQuery(context.Background(), NewQuery("x"), nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("want *APIError, got %v", err)
+	}
+	if !IsValidationFailed(err) || IsRateLimited(err) || IsUnauthorized(err) {
+		t.Fatalf("helpers %v", err)
+	}
+	if apiErr.DocumentationURL != "https://docs.github.com/v3/search/" || len(apiErr.Errors) != 2 || apiErr.Errors[0].Field != "q" {
+		t.Fatalf("%+v", apiErr)
+	}
+	if got := err.Error(); got != "github: 422 Validation Failed: bad query; plain" {
+		t.Fatalf("Error() %s", got)
+	}
+}
@@ -4,21 +4,24 @@ go 1.19
 
 require (
 	github.com/PuerkitoBio/goquery v1.8.0
+	github.com/alecthomas/chroma/v2 v2.14.0
 	github.com/gizak/termui/v3 v3.1.0
 	github.com/go-resty/resty/v2 v2.7.0
 	github.com/manifoldco/promptui v0.9.0
+	github.com/mattn/go-runewidth v0.0.9
 	github.com/spf13/cobra v1.5.0
-	golang.org/x/sync v0.1.0
+	go.etcd.io/bbolt v1.3.9
+	golang.org/x/sync v0.5.0
 )
 
 require (
 	github.com/andybalholm/cascadia v1.3.1 // indirect
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
+	github.com/dlclark/regexp2 v1.11.0 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	github.com/mattn/go-runewidth v0.0.9 // indirect
 	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7 // indirect
 	github.com/nsf/termbox-go v0.0.0-20190121233118-02980233997d // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	golang.org/x/net v0.0.0-20211029224645-99673261e6eb // indirect
-	golang.org/x/sys v0.0.0-20210423082822-04245dca01da // indirect
+	golang.org/x/sys v0.10.0 // indirect
 )
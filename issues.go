@@ -0,0 +1,104 @@
+package ghsearch
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// SimpleUser user brief in search results
+type SimpleUser struct {
+	Login     string `json:"login"`
+	ID        int    `json:"id"`
+	NodeID    string `json:"node_id"`
+	AvatarURL string `json:"avatar_url"`
+	URL       string `json:"url"`
+	HTMLURL   string `json:"html_url"`
+	Type      string `json:"type"`
+	SiteAdmin bool   `json:"site_admin"`
+}
+
+// Label issue label
+type Label struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+}
+
+// SearchIssueResult one page of issue and pull request search result
+type SearchIssueResult = SearchResult[SearchIssueResultItems]
+
+// SearchIssueResultItems issue or pull request
+type SearchIssueResultItems struct {
+	ID            int          `json:"id"`
+	NodeID        string       `json:"node_id"`
+	Number        int          `json:"number"`
+	Title         string       `json:"title"`
+	Body          string       `json:"body"`
+	State         string       `json:"state"` // open/closed
+	StateReason   string       `json:"state_reason"`
+	Locked        bool         `json:"locked"`
+	Draft         bool         `json:"draft"`
+	URL           string       `json:"url"`
+	HTMLURL       string       `json:"html_url"`
+	RepositoryURL string       `json:"repository_url"`
+	CommentsURL   string       `json:"comments_url"`
+	User          SimpleUser   `json:"user"`
+	Labels        []Label      `json:"labels"`
+	Assignee      *SimpleUser  `json:"assignee"`
+	Assignees     []SimpleUser `json:"assignees"`
+	Milestone     *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+	} `json:"milestone"`
+	Comments          int        `json:"comments"`
+	AuthorAssociation string     `json:"author_association"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	ClosedAt          *time.Time `json:"closed_at"`
+	PullRequest       *struct {
+		URL      string     `json:"url"`
+		HTMLURL  string     `json:"html_url"`
+		DiffURL  string     `json:"diff_url"`
+		PatchURL string     `json:"patch_url"`
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+	Reactions struct {
+		TotalCount int `json:"total_count"`
+	} `json:"reactions"`
+	Score float32 `json:"score"`
+}
+
+// IsPullRequest it's a pull request, not an issue
+func (i *SearchIssueResultItems) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// RepositoryName owner/name parsed from RepositoryURL
+func (i *SearchIssueResultItems) RepositoryName() string {
+	parts := strings.Split(strings.TrimRight(i.RepositoryURL, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+
+// SearchIssues search issues and pull requests by keywords, which may contain qualifiers like is:open label:bug.
+// it's the first page of SearchResult in server's order, see SearchIssuesQuery and SearchIssuesIter for options and more pages
+func (c *Client) SearchIssues(ctx context.Context, keywords ...string) ([]SearchIssueResultItems, error) {
+	return searchKeywords[SearchIssueResultItems](ctx, c, "/search/issues", issueSorts, keywords)
+}
+
+// SearchIssuesQuery search issues and pull requests by structured query. items are in server's order
+// https://docs.github.com/en/rest/search/search#search-issues-and-pull-requests
+func (c *Client) SearchIssuesQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchIssueResult, error) {
+	return search[SearchIssueResultItems](ctx, c, "/search/issues", q, opt, issueSorts)
+}
+
+// SearchIssuesIter iterate all issues and pull requests matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchIssuesIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchIssueResultItems] {
+	return searchIter[SearchIssueResultItems](ctx, c, "/search/issues", q, opt, issueSorts)
+}
@@ -0,0 +1,46 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchIssuesQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/issues" || r.URL.Query().Get("sort") != "comments" {
+			t.Errorf("request %s", r.URL)
+		}
+		w.Write([]byte(`{"total_count":1,"items":[{"number":7,"state":"open","repository_url":"https://api.github.com/repos/golang/go","labels":[{"name":"good first issue"}],"pull_request":{"html_url":"https://github.com/golang/go/pull/7"}}]}`))
+	}))
+	defer srv.Close()
+
+	q := NewQuery().State("open").Label("good first issue").Language("go")
+	r, err := NewClient(WithBaseURL(srv.URL)).SearchIssuesQuery(context.Background(), q, &SearchOptions{Sort: SortComments})
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := r.Items[0]
+	if !it.IsPullRequest() || it.RepositoryName() != "golang/go" || it.Labels[0].Name != "good first issue" {
+		t.Fatalf("%+v", it)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != `is:open label:"good first issue" language:go` {
+			t.Errorf("q %s", q)
+		}
+		w.Write([]byte(`{"total_count":1,"items":[{"number":7,"state":"open"}]}`))
+	}))
+	defer srv.Close()
+
+	items, err := NewClient(WithBaseURL(srv.URL)).SearchIssues(context.Background(), "is:open", `label:"good first issue"`, "language:go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Number != 7 {
+		t.Fatalf("%+v", items)
+	}
+}
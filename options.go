@@ -0,0 +1,116 @@
+package ghsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Sort search result sort field
+type Sort string
+
+const (
+	SortBestMatch        Sort = ""                   // repo/code default, sorted by relevance
+	SortStars            Sort = "stars"              // repo
+	SortForks            Sort = "forks"              // repo
+	SortHelpWantedIssues Sort = "help-wanted-issues" // repo
+	SortUpdated          Sort = "updated"            // repo/issue
+	SortIndexed          Sort = "indexed"            // code
+	SortComments         Sort = "comments"           // issue
+	SortReactions        Sort = "reactions"          // issue
+	SortInteractions     Sort = "interactions"       // issue
+	SortCreated          Sort = "created"            // issue
+	SortFollowers        Sort = "followers"          // user
+	SortRepositories     Sort = "repositories"       // user
+	SortJoined           Sort = "joined"             // user
+	SortAuthorDate       Sort = "author-date"        // commit
+	SortCommitterDate    Sort = "committer-date"     // commit
+)
+
+var (
+	repoSorts   = []Sort{SortBestMatch, SortStars, SortForks, SortHelpWantedIssues, SortUpdated}
+	codeSorts   = []Sort{SortBestMatch, SortIndexed}
+	issueSorts  = []Sort{SortBestMatch, SortComments, SortReactions, SortInteractions, SortCreated, SortUpdated}
+	userSorts   = []Sort{SortBestMatch, SortFollowers, SortRepositories, SortJoined}
+	commitSorts = []Sort{SortBestMatch, SortAuthorDate, SortCommitterDate}
+	topicSorts  = []Sort{SortBestMatch}
+)
+
+// ParseSort parse sort name, best-match or empty means SortBestMatch
+func ParseSort(s string) (Sort, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "best-match" || s == "best_match" {
+		return SortBestMatch, nil
+	}
+	for _, v := range [][]Sort{repoSorts, codeSorts, issueSorts, userSorts, commitSorts} {
+		for _, sort := range v {
+			if string(sort) == s {
+				return sort, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown sort %s", s)
+}
+
+// Order search result order
+type Order string
+
+const (
+	OrderDesc Order = "desc" // default
+	OrderAsc  Order = "asc"
+)
+
+// ParseOrder parse asc/desc, empty means OrderDesc
+func ParseOrder(s string) (Order, error) {
+	switch Order(strings.ToLower(strings.TrimSpace(s))) {
+	case "", OrderDesc:
+		return OrderDesc, nil
+	case OrderAsc:
+		return OrderAsc, nil
+	}
+	return "", fmt.Errorf("unknown order %s", s)
+}
+
+// SearchOptions search options. nil means best match, first page
+type SearchOptions struct {
+	Sort    Sort
+	Order   Order // ignored when Sort is SortBestMatch
+	Page    int   // start from 1
+	PerPage int   // default 30, max 100
+
+	// TextMatch request text_matches of items, which contain matched fragments
+	TextMatch bool
+}
+
+// params encode query and options into url query params. sort must be one of valid
+func (o *SearchOptions) params(q *Query, valid []Sort) (string, error) {
+	if o == nil {
+		o = &SearchOptions{}
+	}
+	v := url.Values{}
+	v.Set("q", q.String())
+	if o.Sort != SortBestMatch {
+		ok := false
+		for _, s := range valid {
+			ok = ok || s == o.Sort
+		}
+		if !ok {
+			return "", fmt.Errorf("invalid sort %s", o.Sort)
+		}
+		v.Set("sort", string(o.Sort))
+		if len(o.Order) > 0 {
+			v.Set("order", string(o.Order))
+		}
+	}
+	if o.Page > 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		if o.PerPage > MaxPerPage {
+			return "", fmt.Errorf("per page %d exceeds %d", o.PerPage, MaxPerPage)
+		}
+		v.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	return v.Encode(), nil
+}
@@ -0,0 +1,200 @@
+// Package output print records in plain formats for scripts, e.g. json, csv, markdown table
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Format output format
+type Format string
+
+const (
+	JSON     Format = "json"     // one json array
+	NDJSON   Format = "ndjson"   // one json object per line
+	CSV      Format = "csv"      // header and rows
+	TSV      Format = "tsv"      // tab separated header and rows
+	Table    Format = "table"    // aligned columns for human
+	Markdown Format = "markdown" // markdown table
+	Template Format = "template" // go text/template executed per record
+)
+
+// Formats all supported formats
+var Formats = []Format{JSON, NDJSON, CSV, TSV, Table, Markdown, Template}
+
+// ParseFormat parse format name
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "md" {
+		return Markdown, nil
+	}
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %s, should be one of %v", s, Formats)
+}
+
+// IsTerminal f is a terminal, not a pipe or file
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Column one column of tabular formats
+type Column[T any] struct {
+	Name  string
+	Value func(item T) string
+}
+
+// Printer print records one by one. call Flush at the end
+type Printer[T any] struct {
+	w       io.Writer
+	format  Format
+	columns []Column[T]
+	tmpl    *template.Template
+
+	csv *csv.Writer
+	tw  *tabwriter.Writer
+	n   int // records printed
+}
+
+// NewPrinter create printer. columns are used by csv, tsv, table and markdown,
+// json and ndjson encode the record itself, text is the template of Template format
+func NewPrinter[T any](w io.Writer, format Format, text string, columns []Column[T]) (*Printer[T], error) {
+	p := &Printer[T]{w: w, format: format, columns: columns}
+	switch format {
+	case CSV, TSV:
+		p.csv = csv.NewWriter(w)
+		if format == TSV {
+			p.csv.Comma = '\t'
+		}
+	case Table:
+		p.tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	case Template:
+		if len(text) == 0 {
+			return nil, fmt.Errorf("template is empty")
+		}
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		t, err := template.New("output").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		p.tmpl = t
+	case JSON, NDJSON, Markdown:
+	default:
+		return nil, fmt.Errorf("unknown format %s", format)
+	}
+	return p, nil
+}
+
+// Print print one record
+func (p *Printer[T]) Print(item T) error {
+	if p.n == 0 {
+		if err := p.header(); err != nil {
+			return err
+		}
+	}
+	p.n++
+
+	switch p.format {
+	case JSON:
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ","
+		if p.n == 1 {
+			sep = ""
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n  %s", sep, b)
+		return err
+	case NDJSON:
+		return json.NewEncoder(p.w).Encode(item)
+	case CSV, TSV:
+		return p.csv.Write(p.row(item, cell))
+	case Table:
+		_, err := fmt.Fprintln(p.tw, strings.Join(p.row(item, cell), "\t"))
+		return err
+	case Markdown:
+		_, err := fmt.Fprintf(p.w, "| %s |\n", strings.Join(p.row(item, markdownCell), " | "))
+		return err
+	case Template:
+		return p.tmpl.Execute(p.w, item)
+	}
+	return nil
+}
+
+// Flush finish output. header is still printed if there is no record
+func (p *Printer[T]) Flush() error {
+	if p.n == 0 {
+		if err := p.header(); err != nil {
+			return err
+		}
+	}
+	switch p.format {
+	case JSON:
+		if p.n == 0 {
+			_, err := fmt.Fprintln(p.w, "]")
+			return err
+		}
+		_, err := fmt.Fprintln(p.w, "\n]")
+		return err
+	case CSV, TSV:
+		p.csv.Flush()
+		return p.csv.Error()
+	case Table:
+		return p.tw.Flush()
+	}
+	return nil
+}
+
+func (p *Printer[T]) header() error {
+	names := make([]string, 0, len(p.columns))
+	for _, c := range p.columns {
+		names = append(names, c.Name)
+	}
+	switch p.format {
+	case JSON:
+		_, err := fmt.Fprint(p.w, "[")
+		return err
+	case CSV, TSV:
+		return p.csv.Write(names)
+	case Table:
+		_, err := fmt.Fprintln(p.tw, strings.ToUpper(strings.Join(names, "\t")))
+		return err
+	case Markdown:
+		_, err := fmt.Fprintf(p.w, "| %s |\n|%s\n", strings.Join(names, " | "), strings.Repeat(" --- |", len(names)))
+		return err
+	}
+	return nil
+}
+
+func (p *Printer[T]) row(item T, clean func(string) string) []string {
+	row := make([]string, 0, len(p.columns))
+	for _, c := range p.columns {
+		row = append(row, clean(c.Value(item)))
+	}
+	return row
+}
+
+// cell keep one record in one line
+func cell(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(cell(s), "|", `\|`)
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type record struct {
+	Name  string `json:"name"`
+	Stars int    `json:"stars"`
+}
+
+var columns = []Column[record]{
+	{Name: "name", Value: func(r record) string { return r.Name }},
+	{Name: "desc", Value: func(r record) string { return "a|b\nc" }},
+}
+
+func TestPrinter(t *testing.T) {
+	records := []record{{"foo", 1}, {"bar", 2}}
+	tests := []struct {
+		format Format
+		text   string
+		want   string
+	}{
+		{CSV, "", "name,desc\nfoo,a|b c\nbar,a|b c\n"},
+		{TSV, "", "name\tdesc\nfoo\ta|b c\nbar\ta|b c\n"},
+		{Table, "", "NAME  DESC\nfoo   a|b c\nbar   a|b c\n"},
+		{Markdown, "", "| name | desc |\n| --- | --- |\n| foo | a\\|b c |\n| bar | a\\|b c |\n"},
+		{NDJSON, "", "{\"name\":\"foo\",\"stars\":1}\n{\"name\":\"bar\",\"stars\":2}\n"},
+		{Template, "{{.Name}}={{.Stars}}", "foo=1\nbar=2\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		p, err := NewPrinter(&b, tt.format, tt.text, columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range records {
+			if err := p.Print(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := p.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.format, b.String(), tt.want)
+		}
+	}
+}
+
+func TestPrinterJSON(t *testing.T) {
+	for _, n := range []int{0, 1, 3} {
+		var b bytes.Buffer
+		p, _ := NewPrinter(&b, JSON, "", columns)
+		for i := 0; i < n; i++ {
+			p.Print(record{Name: "foo", Stars: i})
+		}
+		p.Flush()
+		var got []record
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil || len(got) != n {
+			t.Fatalf("%d records: %q %v", n, b.String(), err)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("MD"); err != nil || f != Markdown {
+		t.Fatal(f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatal("xml should be unknown")
+	}
+}
@@ -0,0 +1,160 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// DefaultPerPage github default page size
+	DefaultPerPage = 30
+	// MaxPerPage max page size github allows
+	MaxPerPage = 100
+	// MaxSearchResults github only provides up to 1000 results for each search
+	MaxSearchResults = 1000
+)
+
+// Links page numbers parsed from Link header. 0 means no such page
+type Links struct {
+	First int
+	Prev  int
+	Next  int
+	Last  int
+}
+
+// Response meta data of an api response
+type Response struct {
+	StatusCode int
+	Links      Links
+	Rate       RateLimit
+}
+
+// SearchResult one page of search result
+type SearchResult[T any] struct {
+	TotalCount        int  `json:"total_count"`
+	IncompleteResults bool `json:"incomplete_results"`
+	Items             []T  `json:"items"`
+
+	Response `json:"-"`
+}
+
+// TotalPages number of pages can be fetched with perPage, up to MaxSearchResults
+func (r *SearchResult[T]) TotalPages(perPage int) int {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	total := r.TotalCount
+	if total > MaxSearchResults {
+		total = MaxSearchResults
+	}
+	return (total + perPage - 1) / perPage
+}
+
+var linkRe = regexp.MustCompile(`<([^>]+)>;\s*rel="(\w+)"`)
+
+// parseLinks parse Link header
+// <https://api.github.com/search/code?q=x&page=2>; rel="next", <https://api.github.com/search/code?q=x&page=34>; rel="last"
+func parseLinks(h http.Header) Links {
+	l := Links{}
+	for _, m := range linkRe.FindAllStringSubmatch(h.Get("Link"), -1) {
+		u, err := url.Parse(m[1])
+		if err != nil {
+			continue
+		}
+		page, _ := strconv.Atoi(u.Query().Get("page"))
+		switch m[2] {
+		case "first":
+			l.First = page
+		case "prev":
+			l.Prev = page
+		case "next":
+			l.Next = page
+		case "last":
+			l.Last = page
+		}
+	}
+	return l
+}
+
+// Iterator walk search results page by page transparently
+/*
+	it := client.SearchRepoIter(ctx, q, nil)
+	for it.Next() {
+		fmt.Println(it.Item().FullName)
+	}
+	if err := it.Err(); err != nil {
+		...
+	}
+*/
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context, page int) (*SearchResult[T], error)
+	page    int
+	perPage int
+
+	last    *SearchResult[T]
+	items   []T
+	idx     int // index in items
+	visited int // items walked
+	cur     T
+	err     error
+	done    bool
+}
+
+func newIterator[T any](ctx context.Context, opt *SearchOptions, fetch func(ctx context.Context, page int) (*SearchResult[T], error)) *Iterator[T] {
+	it := &Iterator[T]{ctx: ctx, fetch: fetch, page: 1, perPage: DefaultPerPage}
+	if opt != nil {
+		if opt.Page > 0 {
+			it.page = opt.Page
+		}
+		if opt.PerPage > 0 {
+			it.perPage = opt.PerPage
+		}
+	}
+	it.visited = (it.page - 1) * it.perPage
+	return it
+}
+
+// Next advance to next item, fetch next page if needed. return false when no more items or error
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || it.visited >= MaxSearchResults {
+		return false
+	}
+	for it.idx >= len(it.items) {
+		if it.done {
+			return false
+		}
+		r, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.last = r
+		it.items = r.Items
+		it.idx = 0
+		it.done = r.Links.Next == 0 || len(r.Items) == 0
+		it.page = r.Links.Next
+	}
+	it.cur = it.items[it.idx]
+	it.idx++
+	it.visited++
+	return true
+}
+
+// Item current item
+func (it *Iterator[T]) Item() T {
+	return it.cur
+}
+
+// Err first error while fetching
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Result last fetched page. nil before first Next
+func (it *Iterator[T]) Result() *SearchResult[T] {
+	return it.last
+}
@@ -0,0 +1,50 @@
+package ghsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestParseLinks(t *testing.T) {
+	h := http.Header{}
+	h.Set("Link", `<https://api.github.com/search/code?q=x&page=1>; rel="prev", <https://api.github.com/search/code?q=x&page=3>; rel="next", <https://api.github.com/search/code?q=x&page=34>; rel="last", <https://api.github.com/search/code?q=x&page=1>; rel="first"`)
+	if got := parseLinks(h); got != (Links{First: 1, Prev: 1, Next: 3, Last: 34}) {
+		t.Fatalf("%+v", got)
+	}
+}
+
+func TestSearchRepoIter(t *testing.T) {
+	const pages = 3
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if r.URL.Query().Get("per_page") != "2" {
+			t.Errorf("per_page %s", r.URL.Query().Get("per_page"))
+		}
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/search/repositories?page=%d>; rel="next"`, srv.URL, page+1))
+		}
+		fmt.Fprintf(w, `{"total_count":6,"items":[{"id":%d},{"id":%d}]}`, page*2-1, page*2)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	it := c.SearchRepoIter(context.Background(), NewQuery("x"), &SearchOptions{PerPage: 2})
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5 6]" {
+		t.Fatalf("ids %v", ids)
+	}
+	if r := it.Result(); r.TotalCount != 6 || r.TotalPages(2) != 3 {
+		t.Fatalf("result %+v", r)
+	}
+}
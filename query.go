@@ -0,0 +1,391 @@
+package ghsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Term one term of search query. Key is empty for a keyword
+type Term struct {
+	Key   string
+	Value string
+	Not   bool
+}
+
+// String format term as it's written in query
+func (t Term) String() string {
+	if len(t.Key) == 0 {
+		if t.Not {
+			return "NOT " + quote(t.Value)
+		}
+		return quote(t.Value)
+	}
+	s := t.Key + ":" + quote(t.Value)
+	if t.Not {
+		s = "-" + s
+	}
+	return s
+}
+
+// Query github search query builder
+// https://docs.github.com/en/search-github/searching-on-github
+/*
+	q := NewQuery("grpc").Language("go").Stars(">100").Archived(false)
+	q.String() // grpc language:go stars:>100 archived:false
+*/
+type Query struct {
+	Terms []Term
+}
+
+// NewQuery create a query with keywords
+func NewQuery(keywords ...string) *Query {
+	return (&Query{}).Keyword(keywords...)
+}
+
+// Keyword add keywords
+func (q *Query) Keyword(words ...string) *Query {
+	for _, w := range words {
+		if w = strings.TrimSpace(w); len(w) > 0 {
+			q.Terms = append(q.Terms, Term{Value: w})
+		}
+	}
+	return q
+}
+
+// NotKeyword exclude results containing word
+func (q *Query) NotKeyword(word string) *Query {
+	q.Terms = append(q.Terms, Term{Value: word, Not: true})
+	return q
+}
+
+// Qualifier add a qualifier key:value
+func (q *Query) Qualifier(key, value string) *Query {
+	q.Terms = append(q.Terms, Term{Key: key, Value: value})
+	return q
+}
+
+// Not add a negated qualifier -key:value
+func (q *Query) Not(key, value string) *Query {
+	q.Terms = append(q.Terms, Term{Key: key, Value: value, Not: true})
+	return q
+}
+
+// Keywords all keywords which are not negated
+func (q *Query) Keywords() []string {
+	var ret []string
+	for _, t := range q.Terms {
+		if len(t.Key) == 0 && !t.Not {
+			ret = append(ret, t.Value)
+		}
+	}
+	return ret
+}
+
+// Get value of the first qualifier with key
+func (q *Query) Get(key string) (string, bool) {
+	for _, t := range q.Terms {
+		if t.Key == key && !t.Not {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// HasQualifier query has qualifier with key
+func (q *Query) HasQualifier(key string) bool {
+	_, ok := q.Get(key)
+	return ok
+}
+
+// String raw query, e.g. `grpc language:go stars:>100`
+func (q *Query) String() string {
+	parts := make([]string, 0, len(q.Terms))
+	for _, t := range q.Terms {
+		parts = append(parts, t.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// Encode url escaped query
+func (q *Query) Encode() string {
+	return url.QueryEscape(q.String())
+}
+
+// Clone deep copy
+func (q *Query) Clone() *Query {
+	return &Query{Terms: append([]Term(nil), q.Terms...)}
+}
+
+// Language language:go
+func (q *Query) Language(lang string) *Query { return q.Qualifier("language", lang) }
+
+// In in:name,description,readme,topics / in:file,path
+func (q *Query) In(fields ...string) *Query { return q.Qualifier("in", strings.Join(fields, ",")) }
+
+// Repo repo:owner/name
+func (q *Query) Repo(fullName string) *Query { return q.Qualifier("repo", fullName) }
+
+// User user:login
+func (q *Query) User(login string) *Query { return q.Qualifier("user", login) }
+
+// Org org:name
+func (q *Query) Org(org string) *Query { return q.Qualifier("org", org) }
+
+// Size size:>1000 (KB for repository, bytes for code)
+func (q *Query) Size(r string) *Query { return q.Qualifier("size", r) }
+
+// Followers followers:>=100
+func (q *Query) Followers(r string) *Query { return q.Qualifier("followers", r) }
+
+// Forks forks:10..50
+func (q *Query) Forks(r string) *Query { return q.Qualifier("forks", r) }
+
+// Stars stars:>100
+func (q *Query) Stars(r string) *Query { return q.Qualifier("stars", r) }
+
+// Created created:>2024-01-01
+func (q *Query) Created(r string) *Query { return q.Qualifier("created", r) }
+
+// Pushed pushed:>2024-01-01
+func (q *Query) Pushed(r string) *Query { return q.Qualifier("pushed", r) }
+
+// Topic topic:grpc
+func (q *Query) Topic(topic string) *Query { return q.Qualifier("topic", topic) }
+
+// Topics number of topics, topics:>5
+func (q *Query) Topics(r string) *Query { return q.Qualifier("topics", r) }
+
+// License license:mit
+func (q *Query) License(key string) *Query { return q.Qualifier("license", key) }
+
+// Is is:public/private/internal/template/sponsorable
+func (q *Query) Is(v string) *Query { return q.Qualifier("is", v) }
+
+// Mirror mirror:true
+func (q *Query) Mirror(b bool) *Query { return q.Qualifier("mirror", strconv.FormatBool(b)) }
+
+// Template template:true
+func (q *Query) Template(b bool) *Query { return q.Qualifier("template", strconv.FormatBool(b)) }
+
+// Archived archived:false
+func (q *Query) Archived(b bool) *Query { return q.Qualifier("archived", strconv.FormatBool(b)) }
+
+// GoodFirstIssues good-first-issues:>2
+func (q *Query) GoodFirstIssues(r string) *Query { return q.Qualifier("good-first-issues", r) }
+
+// HelpWantedIssues help-wanted-issues:>4
+func (q *Query) HelpWantedIssues(r string) *Query { return q.Qualifier("help-wanted-issues", r) }
+
+// Fork fork:true/only
+func (q *Query) Fork(v string) *Query { return q.Qualifier("fork", v) }
+
+// Has has:funding-file
+func (q *Query) Has(v string) *Query { return q.Qualifier("has", v) }
+
+// Path path:cmd/ (code)
+func (q *Query) Path(p string) *Query { return q.Qualifier("path", p) }
+
+// Filename filename:go.mod (code)
+func (q *Query) Filename(name string) *Query { return q.Qualifier("filename", name) }
+
+// Extension extension:go (code)
+func (q *Query) Extension(ext string) *Query { return q.Qualifier("extension", ext) }
+
+// Type type:pr/issue (issue)
+func (q *Query) Type(t string) *Query { return q.Qualifier("type", t) }
+
+// State state:open/closed (issue)
+func (q *Query) State(state string) *Query { return q.Qualifier("state", state) }
+
+// Label label:"good first issue" (issue)
+func (q *Query) Label(label string) *Query { return q.Qualifier("label", label) }
+
+// Author author:login (issue)
+func (q *Query) Author(login string) *Query { return q.Qualifier("author", login) }
+
+// Assignee assignee:login (issue)
+func (q *Query) Assignee(login string) *Query { return q.Qualifier("assignee", login) }
+
+// Mentions mentions:login (issue)
+func (q *Query) Mentions(login string) *Query { return q.Qualifier("mentions", login) }
+
+// Involves involves:login (issue)
+func (q *Query) Involves(login string) *Query { return q.Qualifier("involves", login) }
+
+// Milestone milestone:v1.0 (issue)
+func (q *Query) Milestone(m string) *Query { return q.Qualifier("milestone", m) }
+
+// No no:label/milestone/assignee/project (issue)
+func (q *Query) No(field string) *Query { return q.Qualifier("no", field) }
+
+// Comments comments:>10 (issue)
+func (q *Query) Comments(r string) *Query { return q.Qualifier("comments", r) }
+
+// Updated updated:>2024-01-01 (issue)
+func (q *Query) Updated(r string) *Query { return q.Qualifier("updated", r) }
+
+// Closed closed:>2024-01-01 (issue)
+func (q *Query) Closed(r string) *Query { return q.Qualifier("closed", r) }
+
+// Merged merged:>2024-01-01 (pull request)
+func (q *Query) Merged(r string) *Query { return q.Qualifier("merged", r) }
+
+// Draft draft:true (pull request)
+func (q *Query) Draft(b bool) *Query { return q.Qualifier("draft", strconv.FormatBool(b)) }
+
+// Location location:iceland (user)
+func (q *Query) Location(loc string) *Query { return q.Qualifier("location", loc) }
+
+// Repos number of repositories, repos:>10 (user)
+func (q *Query) Repos(r string) *Query { return q.Qualifier("repos", r) }
+
+// Fullname fullname:"Linus Torvalds" (user)
+func (q *Query) Fullname(name string) *Query { return q.Qualifier("fullname", name) }
+
+// Committer committer:login (commit)
+func (q *Query) Committer(login string) *Query { return q.Qualifier("committer", login) }
+
+// AuthorEmail author-email:a@b.com (commit)
+func (q *Query) AuthorEmail(email string) *Query { return q.Qualifier("author-email", email) }
+
+// AuthorDate author-date:>2024-01-01 (commit)
+func (q *Query) AuthorDate(r string) *Query { return q.Qualifier("author-date", r) }
+
+// CommitterDate committer-date:>2024-01-01 (commit)
+func (q *Query) CommitterDate(r string) *Query { return q.Qualifier("committer-date", r) }
+
+// Merge merge:true/false (commit)
+func (q *Query) Merge(b bool) *Query { return q.Qualifier("merge", strconv.FormatBool(b)) }
+
+// Hash hash:sha (commit)
+func (q *Query) Hash(sha string) *Query { return q.Qualifier("hash", sha) }
+
+// Repositories number of repositories, repositories:>100 (topic)
+func (q *Query) Repositories(r string) *Query { return q.Qualifier("repositories", r) }
+
+// qualifiers all known qualifier keys
+var qualifiers = map[string]bool{
+	// repositories
+	"in": true, "repo": true, "user": true, "org": true, "size": true, "followers": true,
+	"forks": true, "stars": true, "created": true, "pushed": true, "language": true,
+	"topic": true, "topics": true, "license": true, "is": true, "mirror": true,
+	"template": true, "archived": true, "good-first-issues": true, "help-wanted-issues": true,
+	"fork": true, "has": true,
+	// code
+	"path": true, "filename": true, "extension": true,
+	// issues and pull requests
+	"type": true, "state": true, "reason": true, "author": true, "assignee": true, "mentions": true,
+	"commenter": true, "involves": true, "team": true, "label": true, "milestone": true,
+	"project": true, "status": true, "head": true, "base": true, "no": true, "linked": true,
+	"comments": true, "reactions": true, "interactions": true, "draft": true, "review": true,
+	"reviewed-by": true, "review-requested": true, "user-review-requested": true,
+	"team-review-requested": true, "updated": true, "closed": true, "merged": true, "app": true,
+	"locked": true,
+	// users
+	"location": true, "repos": true, "fullname": true,
+	// commits
+	"committer": true, "author-name": true, "committer-name": true, "author-email": true,
+	"committer-email": true, "author-date": true, "committer-date": true, "merge": true,
+	"hash": true, "parent": true, "tree": true,
+	// topics
+	"repositories": true,
+}
+
+// IsQualifier key is a known search qualifier
+func IsQualifier(key string) bool {
+	return qualifiers[key]
+}
+
+// ParseQuery parse raw query string into structured form.
+// words look like key:value are qualifiers only if key is known, otherwise they are keywords
+func ParseQuery(s string) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{}
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.raw == "NOT" && !tok.quoted && i+1 < len(tokens) {
+			i++
+			q.Terms = append(q.Terms, Term{Value: tokens[i].raw, Not: true})
+			continue
+		}
+		if !tok.quoted {
+			if t, ok := parseQualifier(tok.raw); ok {
+				q.Terms = append(q.Terms, t)
+				continue
+			}
+		}
+		q.Terms = append(q.Terms, Term{Value: tok.raw})
+	}
+	return q, nil
+}
+
+func parseQualifier(s string) (Term, bool) {
+	t := Term{}
+	if strings.HasPrefix(s, "-") {
+		t.Not = true
+		s = s[1:]
+	}
+	idx := strings.Index(s, ":")
+	if idx <= 0 || !IsQualifier(s[:idx]) {
+		return Term{}, false
+	}
+	t.Key, t.Value = s[:idx], strings.Trim(s[idx+1:], `"`)
+	return t, true
+}
+
+type token struct {
+	raw    string
+	quoted bool // whole token is quoted
+}
+
+// tokenize split by space, keep quoted "a b" together
+func tokenize(s string) ([]token, error) {
+	var (
+		tokens  []token
+		cur     strings.Builder
+		inQuote bool
+		quoted  bool
+	)
+	flush := func() {
+		if cur.Len() > 0 {
+			raw := cur.String()
+			if quoted {
+				raw = strings.Trim(raw, `"`)
+			}
+			tokens = append(tokens, token{raw: raw, quoted: quoted})
+		}
+		cur.Reset()
+		quoted = false
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if !inQuote && cur.Len() == 0 {
+				quoted = true
+			}
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unclosed quote in query: %s", s)
+	}
+	flush()
+	return tokens, nil
+}
+
+func quote(s string) string {
+	if strings.ContainsAny(s, " \t") && !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	return s
+}
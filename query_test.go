@@ -0,0 +1,80 @@
+package ghsearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryString(t *testing.T) {
+	q := NewQuery("grpc", "micro service").
+		Language("go").
+		Stars(">100").
+		Pushed(">2024-01-01").
+		In("name", "description").
+		Archived(false).
+		Not("topic", "bot").
+		NotKeyword("deprecated")
+	want := `grpc "micro service" language:go stars:>100 pushed:>2024-01-01 in:name,description archived:false -topic:bot NOT deprecated`
+	if got := q.String(); got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+	if got := NewQuery("a b").Stars(">=1").Encode(); got != "%22a+b%22+stars%3A%3E%3D1" {
+		t.Fatalf("Encode %s", got)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	raw := `grpc "micro service" language:go -org:foo foo:"bar baz" NOT deprecated http://x`
+	q, err := ParseQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Term{
+		{Value: "grpc"},
+		{Value: "micro service"},
+		{Key: "language", Value: "go"},
+		{Key: "org", Value: "foo", Not: true},
+		{Value: `foo:"bar baz"`}, // unknown qualifier is keyword
+		{Value: "deprecated", Not: true},
+		{Value: "http://x"},
+	}
+	if !reflect.DeepEqual(q.Terms, want) {
+		t.Fatalf("got %+v", q.Terms)
+	}
+	if lang, _ := q.Get("language"); lang != "go" {
+		t.Fatalf("language %s", lang)
+	}
+
+	q2, err := ParseQuery(NewQuery("x").Stars("10..20").Not("fork", "true").String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q2.String() != "x stars:10..20 -fork:true" {
+		t.Fatalf("roundtrip %s", q2.String())
+	}
+
+	if _, err := ParseQuery(`"unclosed`); err == nil {
+		t.Fatal("want error")
+	}
+}
+
+func TestKeywordsQuery(t *testing.T) {
+	tests := []struct {
+		lang     string
+		keywords []string
+		want     string
+	}{
+		{"go", []string{"grpc", "stars:>100"}, "grpc stars:>100 language:go"},
+		{"go", []string{"grpc", "language:rust"}, "grpc language:rust"},
+		{"", []string{"grpc"}, "grpc"},
+	}
+	for _, tt := range tests {
+		q, err := KeywordsQuery(tt.lang, tt.keywords...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := q.String(); got != tt.want {
+			t.Errorf("KeywordsQuery(%q, %q) = %s, want %s", tt.lang, tt.keywords, got, tt.want)
+		}
+	}
+}
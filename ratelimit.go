@@ -0,0 +1,95 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit rate limit status of a response
+// https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api
+type RateLimit struct {
+	Limit      int           // X-RateLimit-Limit
+	Remaining  int           // X-RateLimit-Remaining
+	Used       int           // X-RateLimit-Used
+	Reset      time.Time     // X-RateLimit-Reset
+	Resource   string        // X-RateLimit-Resource, core/search/code_search...
+	RetryAfter time.Duration // Retry-After, set by secondary rate limit
+}
+
+// Known rate limit headers were present
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// String e.g. 29/30 reset in 42s
+func (r RateLimit) String() string {
+	if !r.Known() {
+		return "unknown"
+	}
+	s := strconv.Itoa(r.Remaining) + "/" + strconv.Itoa(r.Limit)
+	if d := time.Until(r.Reset); d > 0 {
+		s += " reset in " + d.Round(time.Second).String()
+	}
+	return s
+}
+
+func parseRateLimit(h http.Header) RateLimit {
+	r := RateLimit{Resource: h.Get("X-RateLimit-Resource")}
+	r.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	r.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	r.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = time.Unix(reset, 0)
+	}
+	if sec, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		r.RetryAfter = time.Duration(sec) * time.Second
+	}
+	return r
+}
+
+// secondaryRateLimitWait github suggests waiting at least a minute if there is no hint
+const secondaryRateLimitWait = time.Minute
+
+// rateLimited whether a response is rejected by primary or secondary rate limit, and how long to wait
+func rateLimited(status int, r RateLimit, body []byte) (bool, time.Duration) {
+	if status != http.StatusForbidden && status != http.StatusTooManyRequests {
+		return false, 0
+	}
+	switch {
+	case r.RetryAfter > 0:
+		return true, r.RetryAfter
+	case r.Known() && r.Remaining == 0:
+		wait := time.Until(r.Reset) + time.Second
+		if wait < 0 {
+			wait = time.Second
+		}
+		return true, wait
+	case status == http.StatusTooManyRequests || strings.Contains(strings.ToLower(string(body)), "secondary rate limit"):
+		return true, secondaryRateLimitWait
+	}
+	return false, 0
+}
+
+// WithRateLimitRetry wait and retry at most maxRetries times when rate limited,
+// unless it needs to wait longer than maxWait
+func WithRateLimitRetry(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.maxRetryWait = maxWait
+	}
+}
+
+// sleep wait d or ctx done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
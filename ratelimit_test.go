@@ -0,0 +1,61 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitRetry(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Resource", "search")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "29")
+		w.Write([]byte(`{"total_count":0,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.SearchRepoQuery(context.Background(), NewQuery("x"), nil); err == nil {
+		t.Fatal("want error without retry")
+	}
+
+	calls = 0
+	c = NewClient(WithBaseURL(srv.URL), WithRateLimitRetry(1, 5*time.Second))
+	r, err := c.SearchRepoQuery(context.Background(), NewQuery("x"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls %d", calls)
+	}
+	if r.Rate.Limit != 30 || r.Rate.Remaining != 29 || r.Rate.Resource != "search" {
+		t.Fatalf("rate %+v", r.Rate)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "3")
+	if ok, wait := rateLimited(http.StatusForbidden, parseRateLimit(h), nil); !ok || wait != 3*time.Second {
+		t.Fatalf("retry-after %v %v", ok, wait)
+	}
+	if ok, _ := rateLimited(http.StatusForbidden, RateLimit{Limit: 30, Remaining: 10}, []byte(`{"message":"Forbidden"}`)); ok {
+		t.Fatal("plain 403 is not rate limited")
+	}
+	if ok, wait := rateLimited(http.StatusForbidden, RateLimit{}, []byte(`{"message":"You have exceeded a secondary rate limit"}`)); !ok || wait != secondaryRateLimitWait {
+		t.Fatalf("secondary %v %v", ok, wait)
+	}
+}
@@ -1,21 +1,13 @@
 package ghsearch
 
 import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"sort"
-	"strconv"
+	"context"
+	"strings"
 	"time"
-
-	"github.com/go-resty/resty/v2"
 )
 
-type SearchRepoResult struct {
-	TotalCount        int                     `json:"total_count"`
-	IncompleteResults bool                    `json:"incomplete_results"`
-	Items             []SearchRepoResultItems `json:"items"`
-}
+// SearchRepoResult one page of repository search result
+type SearchRepoResult = SearchResult[SearchRepoResultItems]
 
 type SearchRepoResultItems struct {
 	ID       int    `json:"id"`
@@ -42,76 +34,78 @@ type SearchRepoResultItems struct {
 		EventsURL         string `json:"events_url"`
 		SiteAdmin         bool   `json:"site_admin"`
 	} `json:"owner"`
-	Private          bool      `json:"private"`
-	HTMLURL          string    `json:"html_url"`
-	Description      string    `json:"description"`
-	Fork             bool      `json:"fork"`
-	URL              string    `json:"url"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
-	PushedAt         time.Time `json:"pushed_at"`
-	Homepage         string    `json:"homepage"`
-	Size             int       `json:"size"`
-	StargazersCount  int       `json:"stargazers_count"`
-	WatchersCount    int       `json:"watchers_count"`
-	Language         string    `json:"language"`
-	ForksCount       int       `json:"forks_count"`
-	OpenIssuesCount  int       `json:"open_issues_count"`
-	MasterBranch     string    `json:"master_branch"`
-	DefaultBranch    string    `json:"default_branch"`
-	Score            float32   `json:"score"`
-	ArchiveURL       string    `json:"archive_url"`
-	AssigneesURL     string    `json:"assignees_url"`
-	BlobsURL         string    `json:"blobs_url"`
-	BranchesURL      string    `json:"branches_url"`
-	CollaboratorsURL string    `json:"collaborators_url"`
-	CommentsURL      string    `json:"comments_url"`
-	CommitsURL       string    `json:"commits_url"`
-	CompareURL       string    `json:"compare_url"`
-	ContentsURL      string    `json:"contents_url"`
-	ContributorsURL  string    `json:"contributors_url"`
-	DeploymentsURL   string    `json:"deployments_url"`
-	DownloadsURL     string    `json:"downloads_url"`
-	EventsURL        string    `json:"events_url"`
-	ForksURL         string    `json:"forks_url"`
-	GitCommitsURL    string    `json:"git_commits_url"`
-	GitRefsURL       string    `json:"git_refs_url"`
-	GitTagsURL       string    `json:"git_tags_url"`
-	GitURL           string    `json:"git_url"`
-	IssueCommentURL  string    `json:"issue_comment_url"`
-	IssueEventsURL   string    `json:"issue_events_url"`
-	IssuesURL        string    `json:"issues_url"`
-	KeysURL          string    `json:"keys_url"`
-	LabelsURL        string    `json:"labels_url"`
-	LanguagesURL     string    `json:"languages_url"`
-	MergesURL        string    `json:"merges_url"`
-	MilestonesURL    string    `json:"milestones_url"`
-	NotificationsURL string    `json:"notifications_url"`
-	PullsURL         string    `json:"pulls_url"`
-	ReleasesURL      string    `json:"releases_url"`
-	SSHURL           string    `json:"ssh_url"`
-	StargazersURL    string    `json:"stargazers_url"`
-	StatusesURL      string    `json:"statuses_url"`
-	SubscribersURL   string    `json:"subscribers_url"`
-	SubscriptionURL  string    `json:"subscription_url"`
-	TagsURL          string    `json:"tags_url"`
-	TeamsURL         string    `json:"teams_url"`
-	TreesURL         string    `json:"trees_url"`
-	CloneURL         string    `json:"clone_url"`
-	MirrorURL        string    `json:"mirror_url"`
-	HooksURL         string    `json:"hooks_url"`
-	SvnURL           string    `json:"svn_url"`
-	Forks            int       `json:"forks"`
-	OpenIssues       int       `json:"open_issues"`
-	Watchers         int       `json:"watchers"`
-	HasIssues        bool      `json:"has_issues"`
-	HasProjects      bool      `json:"has_projects"`
-	HasPages         bool      `json:"has_pages"`
-	HasWiki          bool      `json:"has_wiki"`
-	HasDownloads     bool      `json:"has_downloads"`
-	Archived         bool      `json:"archived"`
-	Disabled         bool      `json:"disabled"`
-	Visibility       string    `json:"visibility"`
+	Private          bool        `json:"private"`
+	HTMLURL          string      `json:"html_url"`
+	Description      string      `json:"description"`
+	Fork             bool        `json:"fork"`
+	URL              string      `json:"url"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+	PushedAt         time.Time   `json:"pushed_at"`
+	Homepage         string      `json:"homepage"`
+	Size             int         `json:"size"`
+	StargazersCount  int         `json:"stargazers_count"`
+	WatchersCount    int         `json:"watchers_count"`
+	Language         string      `json:"language"`
+	ForksCount       int         `json:"forks_count"`
+	OpenIssuesCount  int         `json:"open_issues_count"`
+	MasterBranch     string      `json:"master_branch"`
+	DefaultBranch    string      `json:"default_branch"`
+	Score            float32     `json:"score"`
+	ArchiveURL       string      `json:"archive_url"`
+	AssigneesURL     string      `json:"assignees_url"`
+	BlobsURL         string      `json:"blobs_url"`
+	BranchesURL      string      `json:"branches_url"`
+	CollaboratorsURL string      `json:"collaborators_url"`
+	CommentsURL      string      `json:"comments_url"`
+	CommitsURL       string      `json:"commits_url"`
+	CompareURL       string      `json:"compare_url"`
+	ContentsURL      string      `json:"contents_url"`
+	ContributorsURL  string      `json:"contributors_url"`
+	DeploymentsURL   string      `json:"deployments_url"`
+	DownloadsURL     string      `json:"downloads_url"`
+	EventsURL        string      `json:"events_url"`
+	ForksURL         string      `json:"forks_url"`
+	GitCommitsURL    string      `json:"git_commits_url"`
+	GitRefsURL       string      `json:"git_refs_url"`
+	GitTagsURL       string      `json:"git_tags_url"`
+	GitURL           string      `json:"git_url"`
+	IssueCommentURL  string      `json:"issue_comment_url"`
+	IssueEventsURL   string      `json:"issue_events_url"`
+	IssuesURL        string      `json:"issues_url"`
+	KeysURL          string      `json:"keys_url"`
+	LabelsURL        string      `json:"labels_url"`
+	LanguagesURL     string      `json:"languages_url"`
+	MergesURL        string      `json:"merges_url"`
+	MilestonesURL    string      `json:"milestones_url"`
+	NotificationsURL string      `json:"notifications_url"`
+	PullsURL         string      `json:"pulls_url"`
+	ReleasesURL      string      `json:"releases_url"`
+	SSHURL           string      `json:"ssh_url"`
+	StargazersURL    string      `json:"stargazers_url"`
+	StatusesURL      string      `json:"statuses_url"`
+	SubscribersURL   string      `json:"subscribers_url"`
+	SubscriptionURL  string      `json:"subscription_url"`
+	TagsURL          string      `json:"tags_url"`
+	TeamsURL         string      `json:"teams_url"`
+	TreesURL         string      `json:"trees_url"`
+	CloneURL         string      `json:"clone_url"`
+	MirrorURL        string      `json:"mirror_url"`
+	HooksURL         string      `json:"hooks_url"`
+	SvnURL           string      `json:"svn_url"`
+	Forks            int         `json:"forks"`
+	OpenIssues       int         `json:"open_issues"`
+	Watchers         int         `json:"watchers"`
+	HasIssues        bool        `json:"has_issues"`
+	HasProjects      bool        `json:"has_projects"`
+	HasPages         bool        `json:"has_pages"`
+	HasWiki          bool        `json:"has_wiki"`
+	HasDownloads     bool        `json:"has_downloads"`
+	Archived         bool        `json:"archived"`
+	Disabled         bool        `json:"disabled"`
+	Visibility       string      `json:"visibility"`
+	Topics           []string    `json:"topics"`
+	TextMatches      []TextMatch `json:"text_matches"` // set if SearchOptions.TextMatch
 	License          struct {
 		Key     string `json:"key"`
 		Name    string `json:"name"`
@@ -132,41 +126,45 @@ type SearchRepoResultItems struct {
 
 */
 func SearchRepo(token string, page int, lang string, keywords ...string) ([]SearchRepoResultItems, error) {
-	client := resty.New()
+	return SearchRepoContext(context.Background(), token, page, lang, keywords...)
+}
 
-	q := "language:" + lang
-	for _, v := range keywords {
-		q += "+"
-		q += v
-	}
-	resp, err := client.R().
-		SetHeader("Accept", "application/vnd.github+json").
-		SetHeader("Authorization", "Bearer "+token).
-		Get("https://api.github.com/search/repositories?" + "q=" + q + "&sort=star&order=desc&page=" + strconv.Itoa(page))
+// SearchRepoContext search repositories with context
+func SearchRepoContext(ctx context.Context, token string, page int, lang string, keywords ...string) ([]SearchRepoResultItems, error) {
+	return NewClient(WithToken(token)).SearchRepoContext(ctx, page, lang, keywords...)
+}
+
+// SearchRepo search repositories
+func (c *Client) SearchRepo(page int, lang string, keywords ...string) ([]SearchRepoResultItems, error) {
+	return c.SearchRepoContext(context.Background(), page, lang, keywords...)
+}
 
+// SearchRepoContext search repositories with context
+func (c *Client) SearchRepoContext(ctx context.Context, page int, lang string, keywords ...string) ([]SearchRepoResultItems, error) {
+	q, err := KeywordsQuery(lang, keywords...)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("error:%s", string(resp.Body()))
-	}
-
-	//fmt.Println(string(resp.Body()))
-	sr := SearchRepoResult{}
-	if err := json.Unmarshal(resp.Body(), &sr); err != nil {
+	r, err := c.SearchRepoQuery(ctx, q, &SearchOptions{Sort: SortStars, Order: OrderDesc, Page: page})
+	if err != nil {
 		return nil, err
 	}
-	sort.Slice(sr.Items, func(i, j int) bool {
-		return sr.Items[i].StargazersCount > sr.Items[j].StargazersCount
-	})
-	return sr.Items, nil
+	return r.Items, nil
 }
 
-type SearchCodeResult struct {
-	TotalCount        int                     `json:"total_count"`
-	IncompleteResults bool                    `json:"incomplete_results"`
-	Items             []SearchCodeResultItems `json:"items"`
+// SearchRepoQuery search repositories by structured query. items are in server's order
+func (c *Client) SearchRepoQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchRepoResult, error) {
+	return search[SearchRepoResultItems](ctx, c, "/search/repositories", q, opt, repoSorts)
 }
+
+// SearchRepoIter iterate all repositories matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchRepoIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchRepoResultItems] {
+	return searchIter[SearchRepoResultItems](ctx, c, "/search/repositories", q, opt, repoSorts)
+}
+
+// SearchCodeResult one page of code search result
+type SearchCodeResult = SearchResult[SearchCodeResultItems]
+
 type SearchCodeResultItems struct {
 	Name       string `json:"name"`
 	Path       string `json:"path"`
@@ -241,7 +239,8 @@ type SearchCodeResultItems struct {
 		DeploymentsURL   string `json:"deployments_url"`
 		ReleasesURL      string `json:"releases_url"`
 	} `json:"repository"`
-	Score float32 `json:"score"`
+	Score       float32     `json:"score"`
+	TextMatches []TextMatch `json:"text_matches"` // set if SearchOptions.TextMatch
 }
 
 // SearchCode search code
@@ -254,32 +253,95 @@ type SearchCodeResultItems struct {
 
 */
 func SearchCode(token string, page int, lang string, keywords ...string) ([]SearchCodeResultItems, error) {
-	client := resty.New()
+	return SearchCodeContext(context.Background(), token, page, lang, keywords...)
+}
+
+// SearchCodeContext search code with context
+func SearchCodeContext(ctx context.Context, token string, page int, lang string, keywords ...string) ([]SearchCodeResultItems, error) {
+	return NewClient(WithToken(token)).SearchCodeContext(ctx, page, lang, keywords...)
+}
+
+// SearchCode search code
+func (c *Client) SearchCode(page int, lang string, keywords ...string) ([]SearchCodeResultItems, error) {
+	return c.SearchCodeContext(context.Background(), page, lang, keywords...)
+}
 
-	q := "language:" + lang
-	for _, v := range keywords {
-		q += "+"
-		q += v
+// SearchCodeContext search code with context
+func (c *Client) SearchCodeContext(ctx context.Context, page int, lang string, keywords ...string) ([]SearchCodeResultItems, error) {
+	q, err := KeywordsQuery(lang, keywords...)
+	if err != nil {
+		return nil, err
 	}
-	resp, err := client.R().
-		SetHeader("Accept", "application/vnd.github+json").
-		SetHeader("Authorization", "Bearer "+token).
-		Get("https://api.github.com/search/code?" + "q=" + q + "&sort=star&order=desc&page=" + strconv.Itoa(page))
+	r, err := c.SearchCodeQuery(ctx, q, &SearchOptions{Page: page})
+	if err != nil {
+		return nil, err
+	}
+	return r.Items, nil
+}
+
+// SearchCodeQuery search code by structured query. items are in server's order
+func (c *Client) SearchCodeQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchCodeResult, error) {
+	return search[SearchCodeResultItems](ctx, c, "/search/code", q, opt, codeSorts)
+}
 
+// SearchCodeIter iterate all code matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchCodeIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchCodeResultItems] {
+	return searchIter[SearchCodeResultItems](ctx, c, "/search/code", q, opt, codeSorts)
+}
+
+// KeywordsQuery build query from keywords, which may contain qualifiers like stars:>100.
+// language qualifier of lang is added if keywords don't have one, empty lang means any
+func KeywordsQuery(lang string, keywords ...string) (*Query, error) {
+	q, err := ParseQuery(strings.Join(keywords, " "))
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("error:%s", string(resp.Body()))
+	if len(lang) > 0 && !q.HasQualifier("language") {
+		q.Language(lang)
 	}
+	return q, nil
+}
 
-	//fmt.Println(string(resp.Body()))
-	sr := SearchCodeResult{}
-	if err := json.Unmarshal(resp.Body(), &sr); err != nil {
+// searchKeywords request the first page of search api by keywords
+func searchKeywords[T any](ctx context.Context, c *Client, path string, sorts []Sort, keywords []string) ([]T, error) {
+	q, err := KeywordsQuery("", keywords...)
+	if err != nil {
+		return nil, err
+	}
+	r, err := search[T](ctx, c, path, q, nil, sorts)
+	if err != nil {
 		return nil, err
 	}
-	sort.Slice(sr.Items, func(i, j int) bool {
-		return sr.Items[i].Score > sr.Items[j].Score
+	return r.Items, nil
+}
+
+// searchIter iterate all pages of search api from opt.Page
+func searchIter[T any](ctx context.Context, c *Client, path string, q *Query, opt *SearchOptions, sorts []Sort) *Iterator[T] {
+	return newIterator(ctx, opt, func(ctx context.Context, page int) (*SearchResult[T], error) {
+		o := SearchOptions{}
+		if opt != nil {
+			o = *opt
+		}
+		o.Page = page
+		return search[T](ctx, c, path, q, &o, sorts)
 	})
-	return sr.Items, nil
+}
+
+// search request one page of search api
+func search[T any](ctx context.Context, c *Client, path string, q *Query, opt *SearchOptions, sorts []Sort) (*SearchResult[T], error) {
+	params, err := opt.params(q, sorts)
+	if err != nil {
+		return nil, err
+	}
+	accept := ""
+	if opt != nil && opt.TextMatch {
+		accept = textMatchMediaType
+	}
+	r := &SearchResult[T]{}
+	resp, err := c.getAccept(ctx, path+"?"+params, accept, r)
+	if err != nil {
+		return nil, err
+	}
+	r.Response = *resp
+	return r, nil
 }
@@ -0,0 +1,64 @@
+package ghsearch
+
+// textMatchMediaType media type to get text_matches in search results
+// https://docs.github.com/en/rest/search/search#text-match-metadata
+const textMatchMediaType = "application/vnd.github.text-match+json"
+
+// TextMatch a fragment of matched property, e.g. file content or description
+type TextMatch struct {
+	ObjectURL  string `json:"object_url"`
+	ObjectType string `json:"object_type"`
+	Property   string `json:"property"` // content/description/name...
+	Fragment   string `json:"fragment"`
+	Matches    []struct {
+		Text    string `json:"text"`
+		Indices []int  `json:"indices"` // [start, end) in fragment
+	} `json:"matches"`
+}
+
+// Segment part of fragment
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// Segments split fragment into matched and unmatched parts in order
+func (t TextMatch) Segments() []Segment {
+	// indices are character offsets, but fall back to byte offsets if the matched text says so
+	units := []rune(t.Fragment)
+	sub := func(i, j int) string { return string(units[i:j]) }
+	size := len(units)
+	if len(t.Matches) > 0 {
+		m := t.Matches[0]
+		byByte := len(m.Indices) == 2 && validRange(m.Indices, len(t.Fragment)) && t.Fragment[m.Indices[0]:m.Indices[1]] == m.Text
+		byRune := len(m.Indices) == 2 && validRange(m.Indices, len(units)) && string(units[m.Indices[0]:m.Indices[1]]) == m.Text
+		if byByte && !byRune {
+			sub = func(i, j int) string { return t.Fragment[i:j] }
+			size = len(t.Fragment)
+		}
+	}
+
+	var (
+		ret  []Segment
+		last = 0
+	)
+	for _, m := range t.Matches {
+		if len(m.Indices) != 2 || !validRange(m.Indices, size) || m.Indices[0] < last {
+			continue
+		}
+		start, end := m.Indices[0], m.Indices[1]
+		if start > last {
+			ret = append(ret, Segment{Text: sub(last, start)})
+		}
+		ret = append(ret, Segment{Text: sub(start, end), Match: true})
+		last = end
+	}
+	if last < size {
+		ret = append(ret, Segment{Text: sub(last, size)})
+	}
+	return ret
+}
+
+func validRange(r []int, size int) bool {
+	return r[0] >= 0 && r[0] < r[1] && r[1] <= size
+}
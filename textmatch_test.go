@@ -0,0 +1,31 @@
+package ghsearch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTextMatchSegments(t *testing.T) {
+	var tm TextMatch
+	err := json.Unmarshal([]byte(`{"property":"content","fragment":"中文 grpc.NewServer()\nuse grpc","matches":[{"text":"grpc","indices":[3,7]},{"text":"grpc","indices":[24,28]}]}`), &tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Segment{
+		{Text: "中文 "},
+		{Text: "grpc", Match: true},
+		{Text: ".NewServer()\nuse "},
+		{Text: "grpc", Match: true},
+	}
+	if got := tm.Segments(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v", got)
+	}
+
+	// byte offsets
+	tm.Matches[0].Indices = []int{7, 11}
+	tm.Matches = tm.Matches[:1]
+	if got := tm.Segments(); len(got) != 3 || got[1] != (Segment{Text: "grpc", Match: true}) {
+		t.Fatalf("got %+v", got)
+	}
+}
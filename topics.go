@@ -0,0 +1,43 @@
+package ghsearch
+
+import (
+	"context"
+	"time"
+)
+
+// SearchTopicResult one page of topic search result
+type SearchTopicResult = SearchResult[SearchTopicResultItems]
+
+// SearchTopicResultItems topic
+type SearchTopicResultItems struct {
+	Name             string    `json:"name"`
+	DisplayName      string    `json:"display_name"`
+	ShortDescription string    `json:"short_description"`
+	Description      string    `json:"description"`
+	CreatedBy        string    `json:"created_by"`
+	Released         string    `json:"released"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	Featured         bool      `json:"featured"`
+	Curated          bool      `json:"curated"`
+	RepositoryCount  int       `json:"repository_count"`
+	LogoURL          string    `json:"logo_url"`
+	Score            float32   `json:"score"`
+}
+
+// SearchTopics search topics by keywords, which may contain qualifiers like is:featured.
+// it's the first page of SearchResult in server's order, see SearchTopicsQuery and SearchTopicsIter for options and more pages
+func (c *Client) SearchTopics(ctx context.Context, keywords ...string) ([]SearchTopicResultItems, error) {
+	return searchKeywords[SearchTopicResultItems](ctx, c, "/search/topics", topicSorts, keywords)
+}
+
+// SearchTopicsQuery search topics by structured query. items are in server's order
+// https://docs.github.com/en/rest/search/search#search-topics
+func (c *Client) SearchTopicsQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchTopicResult, error) {
+	return search[SearchTopicResultItems](ctx, c, "/search/topics", q, opt, topicSorts)
+}
+
+// SearchTopicsIter iterate all topics matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchTopicsIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchTopicResultItems] {
+	return searchIter[SearchTopicResultItems](ctx, c, "/search/topics", q, opt, topicSorts)
+}
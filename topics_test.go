@@ -0,0 +1,37 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchTopics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/topics":
+			w.Write([]byte(`{"total_count":1,"items":[{"name":"grpc","display_name":"gRPC","featured":true}]}`))
+		case "/search/repositories":
+			if q := r.URL.Query().Get("q"); q != "topic:grpc" {
+				t.Errorf("q %s", q)
+			}
+			w.Write([]byte(`{"total_count":1,"items":[{"name":"grpc-go","topics":["grpc","go"]}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+	topics, err := c.SearchTopicsQuery(ctx, NewQuery("grpc").Is("featured"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos, err := c.SearchRepoQuery(ctx, NewQuery().Topic(topics.Items[0].Name), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repos.Items[0].Topics; len(got) != 2 || got[0] != "grpc" {
+		t.Fatalf("topics %v", got)
+	}
+}
@@ -1,8 +1,12 @@
 package ghsearch
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -13,100 +17,341 @@ import (
 
 // Repository represent a repository in the trending list.
 type Repository struct {
-	Author  string
-	Name    string
-	Link    string
-	Desc    string
-	Lang    string
-	Stars   int
-	Forks   int
-	Add     int
-	BuiltBy []string
+	Author  string        `json:"author"`
+	Name    string        `json:"name"`
+	Link    string        `json:"link"`
+	Desc    string        `json:"desc"`
+	Lang    string        `json:"lang"`
+	Stars   int           `json:"stars"`
+	Forks   int           `json:"forks"`
+	Add     int           `json:"add"` // stars added in Period
+	Period  DateRange     `json:"period"`
+	BuiltBy []Contributor `json:"built_by"`
 }
 
+// Contributor one of the top contributors of a trending repository
+type Contributor struct {
+	Login  string `json:"login"`
+	Link   string `json:"link"`
+	Avatar string `json:"avatar"`
+}
+
+// UnmarshalJSON built by used to be avatar urls
+func (c *Contributor) UnmarshalJSON(b []byte) error {
+	var avatar string
+	if err := json.Unmarshal(b, &avatar); err == nil {
+		c.Avatar = avatar
+		return nil
+	}
+	type contributor Contributor
+	return json.Unmarshal(b, (*contributor)(c))
+}
+
+// periods date range of star gain text like "1,077 stars today"
+var periods = map[string]DateRange{
+	"today":      DateRangeDaily,
+	"this week":  DateRangeWeekly,
+	"this month": DateRangeMonthly,
+}
+
+// starGainRe star gain text like "1,077 stars today" or "1 star this week"
+var starGainRe = regexp.MustCompile(`^([\d,]+)\s+stars?\s+(today|this week|this month)$`)
+
 // Developer represent a developer in the developer trending list.
 type Developer struct {
-	Name        string
-	Username    string
-	PopularRepo string
-	Desc        string
+	Name            string `json:"name"` // display name, same as Username if not set
+	Username        string `json:"username"`
+	Link            string `json:"link"`
+	Avatar          string `json:"avatar"`
+	PopularRepo     string `json:"popular_repo"`
+	PopularRepoLink string `json:"popular_repo_link"`
+	Desc            string `json:"desc"` // description of PopularRepo
 }
 
+// GitHubURL default web base url
 const GitHubURL = "https://github.com"
 
 // TrendingRepos fetch all repositories from  GitHub trending.
-// lang go/c/c++/java/c#/rust...
+// lang go/c/c++/java/c#/rust... see LanguageSlug
 // spokenLang [zh/en/de/fr...] empty means any
 // dataRange daily/weekly/monthly
 func TrendingRepos(lang string, dateRange string, spokenLang string) ([]*Repository, error) {
-	url := fmt.Sprintf("%s/trending/%s?spoken_language_code=%s&since=%s", GitHubURL, lang, spokenLang, dateRange)
-	resp, err := http.Get(url)
+	return TrendingReposContext(context.Background(), lang, dateRange, spokenLang)
+}
+
+// TrendingReposContext fetch all repositories from GitHub trending with context
+func TrendingReposContext(ctx context.Context, lang string, dateRange string, spokenLang string) ([]*Repository, error) {
+	return NewClient().TrendingReposContext(ctx, lang, dateRange, spokenLang)
+}
+
+// TrendingRepos fetch all repositories from trending page of the client's web url
+func (c *Client) TrendingRepos(lang string, dateRange string, spokenLang string) ([]*Repository, error) {
+	return c.TrendingReposContext(context.Background(), lang, dateRange, spokenLang)
+}
+
+// TrendingReposContext fetch all repositories from trending page of the client's web url with context.
+// dateRange and spokenLang are parsed by ParseDateRange and ParseSpokenLanguage, see TrendingReposFiltered
+func (c *Client) TrendingReposContext(ctx context.Context, lang string, dateRange string, spokenLang string) ([]*Repository, error) {
+	f, err := NewTrendingFilter(lang, dateRange, spokenLang)
 	if err != nil {
 		return nil, err
 	}
+	return c.TrendingReposFiltered(ctx, f)
+}
 
-	defer resp.Body.Close()
+// TrendingReposFiltered fetch all repositories from GitHub trending by filter
+func TrendingReposFiltered(ctx context.Context, f TrendingFilter) ([]*Repository, error) {
+	return NewClient().TrendingReposFiltered(ctx, f)
+}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+// TrendingReposFiltered fetch all repositories from trending page of the client's web url by filter.
+// invalid filter is an error instead of an empty list.
+// repositories are still returned with *ParseError if some fields of them are missing
+func (c *Client) TrendingReposFiltered(ctx context.Context, f TrendingFilter) ([]*Repository, error) {
+	slug, err := f.check()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := c.fetchTrending(ctx, fmt.Sprintf("/trending/%s?spoken_language_code=%s&since=%s", slug, f.SpokenLang, f.DateRange))
 	if err != nil {
 		return nil, err
 	}
+	return parseTrending(doc, c.webURL)
+}
 
-	repos := make([]*Repository, 0, 10)
-	doc.Find(".Box .Box-row").Each(func(i int, s *goquery.Selection) {
+// ParseTrending parse repositories from trending page, links are under GitHubURL.
+// repositories are still returned with *ParseError if some fields of them are missing
+func ParseTrending(r io.Reader) ([]*Repository, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseTrending(doc, GitHubURL)
+}
+
+// ParseError fields missing or malformed in trending page, usually because github changed its markup
+type ParseError struct {
+	Warnings []ParseWarning
+}
+
+// ParseWarning one missing or malformed field
+type ParseWarning struct {
+	Row   int    // index of repository in page, -1 means the whole page
+	Field string // e.g. stars
+	Value string // raw text, empty if missing
+}
+
+func (w ParseWarning) String() string {
+	where := "page"
+	if w.Row >= 0 {
+		where = fmt.Sprintf("row %d", w.Row+1)
+	}
+	if len(w.Value) == 0 {
+		return fmt.Sprintf("%s: %s missing", where, w.Field)
+	}
+	return fmt.Sprintf("%s: bad %s %q", where, w.Field, w.Value)
+}
+
+func (e *ParseError) Error() string {
+	const maxShown = 3
+	msgs := make([]string, 0, maxShown)
+	for i, w := range e.Warnings {
+		if i == maxShown {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(e.Warnings)-maxShown))
+			break
+		}
+		msgs = append(msgs, w.String())
+	}
+	return "trending: parse: " + strings.Join(msgs, "; ")
+}
+
+func parseTrending(doc *goquery.Document, webURL string) ([]*Repository, error) {
+	var warnings []ParseWarning
+	warn := func(row int, field, value string) {
+		warnings = append(warnings, ParseWarning{Row: row, Field: field, Value: value})
+	}
+
+	rows := doc.Find(".Box .Box-row")
+	if rows.Length() == 0 && doc.Find(".blankslate").Length() == 0 {
+		warn(-1, "repositories", "")
+	}
+
+	repos := make([]*Repository, 0, rows.Length())
+	rows.Each(func(i int, s *goquery.Selection) {
 		repo := &Repository{}
 
-		// author name link
-		titleSel := s.Find("h1 a")
-		repo.Author = strings.Trim(titleSel.Find("span").Text(), "/\n ")
-		repo.Name = strings.TrimSpace(titleSel.Contents().Last().Text())
+		// author name link, from href like /author/name
+		titleSel := s.Find("h2 a, h1 a").First()
 		relativeLink, _ := titleSel.Attr("href")
-		if len(relativeLink) > 0 {
-			repo.Link = GitHubURL + relativeLink
+		author, name, ok := strings.Cut(strings.Trim(relativeLink, "/"), "/")
+		if !ok || len(author) == 0 || len(name) == 0 || strings.Contains(name, "/") {
+			warn(i, "link", relativeLink)
+			return
 		}
+		repo.Author, repo.Name = author, name
+		repo.Link = webURL + relativeLink
 
-		// desc
+		// desc, optional
 		repo.Desc = strings.TrimSpace(s.Find("p").Text())
 
-		var langIdx, addIdx, builtByIdx int
-		spanSel := s.Find("div>span")
-		if spanSel.Size() == 2 {
-			// language not exist
-			langIdx = -1
-			addIdx = 1
-		} else {
-			builtByIdx = 1
-			addIdx = 2
+		// language, optional
+		repo.Lang = strings.TrimSpace(s.Find(`[itemprop="programmingLanguage"]`).Text())
+		if len(repo.Lang) == 0 {
+			repo.Lang = "unknown"
 		}
 
-		// language
-		if langIdx >= 0 {
-			repo.Lang = strings.TrimSpace(spanSel.Eq(langIdx).Text())
-		} else {
-			repo.Lang = "unknown"
+		// stars forks
+		var err error
+		starStr := strings.TrimSpace(s.Find(`a[href$="/stargazers"]`).Text())
+		if repo.Stars, err = parseCount(starStr); err != nil {
+			warn(i, "stars", starStr)
+		}
+		forkSel := s.Find(`a[href$="/forks"]`)
+		if forkSel.Length() == 0 {
+			forkSel = s.Find(`a[href$="/network/members"]`)
+		}
+		forkStr := strings.TrimSpace(forkSel.Text())
+		if repo.Forks, err = parseCount(forkStr); err != nil {
+			warn(i, "forks", forkStr)
 		}
 
-		// add
-		addParts := strings.SplitN(strings.TrimSpace(spanSel.Eq(addIdx).Text()), " ", 2)
-		repo.Add, _ = strconv.Atoi(addParts[0])
+		// add and period, like "1,077 stars today"
+		addStr := strings.Join(strings.Fields(s.Find("span.float-sm-right").Text()), " ")
+		if m := starGainRe.FindStringSubmatch(addStr); m != nil {
+			repo.Add, _ = parseCount(m[1])
+			repo.Period = periods[m[2]]
+		} else {
+			warn(i, "add", addStr)
+		}
 
-		// builtby
-		spanSel.Eq(builtByIdx).Find("a>img").Each(func(i int, img *goquery.Selection) {
-			src, _ := img.Attr("src")
-			repo.BuiltBy = append(repo.BuiltBy, src)
+		// builtby, optional
+		s.Find("span").FilterFunction(func(_ int, span *goquery.Selection) bool {
+			return strings.HasPrefix(strings.TrimSpace(span.Text()), "Built by")
+		}).Find("a").Each(func(i int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			login := strings.Trim(href, "/")
+			if len(login) == 0 || strings.Contains(login, "/") {
+				alt, _ := a.Find("img").Attr("alt")
+				login = strings.TrimPrefix(alt, "@")
+			}
+			if len(login) == 0 {
+				return
+			}
+			c := Contributor{Login: login, Link: webURL + "/" + login}
+			c.Avatar, _ = a.Find("img").Attr("src")
+			repo.BuiltBy = append(repo.BuiltBy, c)
 		})
 
-		// stars forks
-		aSel := s.Find("div>a")
-		starStr := strings.TrimSpace(aSel.Eq(-2).Text())
-		star, _ := strconv.Atoi(strings.Replace(starStr, ",", "", -1))
-		repo.Stars = star
-		forkStr := strings.TrimSpace(aSel.Eq(-1).Text())
-		fork, _ := strconv.Atoi(strings.Replace(forkStr, ",", "", -1))
-		repo.Forks = fork
-
 		repos = append(repos, repo)
 	})
 
+	if len(warnings) > 0 {
+		return repos, &ParseError{Warnings: warnings}
+	}
 	return repos, nil
 }
+
+// parseCount parse number like 1,234
+func parseCount(s string) (int, error) {
+	return strconv.Atoi(strings.ReplaceAll(strings.TrimSpace(s), ",", ""))
+}
+
+// TrendingDevelopers fetch all developers from GitHub trending.
+// lang go/c/c++/java/c#/rust... empty means any, see LanguageSlug
+// since daily/weekly/monthly
+func TrendingDevelopers(ctx context.Context, lang string, since string) ([]*Developer, error) {
+	return NewClient().TrendingDevelopers(ctx, lang, since)
+}
+
+// TrendingDevelopers fetch all developers from trending page of the client's web url.
+// since is parsed by ParseDateRange, see TrendingDevelopersFiltered
+func (c *Client) TrendingDevelopers(ctx context.Context, lang string, since string) ([]*Developer, error) {
+	f, err := NewTrendingFilter(lang, since, "")
+	if err != nil {
+		return nil, err
+	}
+	return c.TrendingDevelopersFiltered(ctx, f)
+}
+
+// TrendingDevelopersFiltered fetch all developers from trending page of the client's web url by filter.
+// SpokenLang is ignored since developers can't be filtered by it
+func (c *Client) TrendingDevelopersFiltered(ctx context.Context, f TrendingFilter) ([]*Developer, error) {
+	slug, err := f.check()
+	if err != nil {
+		return nil, err
+	}
+	path := "/trending/developers"
+	if len(slug) > 0 {
+		path += "/" + slug
+	}
+	doc, err := c.fetchTrending(ctx, path+"?since="+string(f.DateRange))
+	if err != nil {
+		return nil, err
+	}
+
+	devs := make([]*Developer, 0, 10)
+	doc.Find(".Box .Box-row").Each(func(i int, s *goquery.Selection) {
+		dev := &Developer{}
+
+		// avatar
+		dev.Avatar, _ = s.Find("img.avatar-user").Attr("src")
+		if len(dev.Avatar) == 0 {
+			dev.Avatar, _ = s.Find("img").First().Attr("src")
+		}
+
+		// name username link
+		nameSel := s.Find("h1.h3 a").First()
+		dev.Name = strings.TrimSpace(nameSel.Text())
+		profile, _ := nameSel.Attr("href")
+		dev.Username = strings.TrimSpace(s.Find("p.f4 a").First().Text())
+		if len(dev.Username) == 0 {
+			dev.Username = strings.Trim(profile, "/")
+		}
+		if len(dev.Name) == 0 {
+			dev.Name = dev.Username
+		}
+		if len(profile) > 0 {
+			dev.Link = c.webURL + profile
+		}
+
+		// popular repo
+		repoSel := s.Find("article")
+		repoLink := repoSel.Find("h1 a").First()
+		dev.PopularRepo = strings.TrimSpace(repoLink.Text())
+		if href, ok := repoLink.Attr("href"); ok {
+			dev.PopularRepoLink = c.webURL + href
+		}
+		descSel := repoSel.Find("div.f6.mt-1")
+		if descSel.Length() == 0 {
+			// markup changed, the label is an h1.f6, but skip it in case it becomes a div
+			descSel = repoSel.Find("div.f6").FilterFunction(func(i int, s *goquery.Selection) bool {
+				return !strings.EqualFold(strings.TrimSpace(s.Text()), "Popular repo")
+			})
+		}
+		dev.Desc = strings.TrimSpace(descSel.Last().Text())
+
+		devs = append(devs, dev)
+	})
+
+	return devs, nil
+}
+
+// fetchTrending get and parse trending page under the client's web url
+func (c *Client) fetchTrending(ctx context.Context, path string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.webURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("trending: %s %s", path, resp.Status)
+	}
+	return goquery.NewDocumentFromReader(resp.Body)
+}
@@ -0,0 +1,188 @@
+package ghsearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DateRange date range of trending
+type DateRange string
+
+const (
+	DateRangeDaily   DateRange = "daily" // default
+	DateRangeWeekly  DateRange = "weekly"
+	DateRangeMonthly DateRange = "monthly"
+)
+
+// DateRanges all date ranges of trending
+var DateRanges = []DateRange{DateRangeDaily, DateRangeWeekly, DateRangeMonthly}
+
+// ParseDateRange parse daily/weekly/monthly, empty means DateRangeDaily
+func ParseDateRange(s string) (DateRange, error) {
+	d := DateRange(strings.ToLower(strings.TrimSpace(s)))
+	if len(d) == 0 {
+		return DateRangeDaily, nil
+	}
+	for _, v := range DateRanges {
+		if v == d {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown date range %s, should be one of %v", s, DateRanges)
+}
+
+// SpokenLanguage ISO 639-1 code of spoken language, e.g. zh/en/de/fr
+type SpokenLanguage string
+
+// SpokenLanguageAny any spoken language
+const SpokenLanguageAny SpokenLanguage = ""
+
+var spokenLanguageRe = regexp.MustCompile(`^[a-z]{2}$`)
+
+// ParseSpokenLanguage parse two letter code of spoken language, empty or any means SpokenLanguageAny.
+// use TrendingSpokenLanguages to get the codes offered by trending page
+func ParseSpokenLanguage(s string) (SpokenLanguage, error) {
+	code := strings.ToLower(strings.TrimSpace(s))
+	if len(code) == 0 || code == "any" {
+		return SpokenLanguageAny, nil
+	}
+	if !spokenLanguageRe.MatchString(code) {
+		return "", fmt.Errorf("unknown spoken language %s, should be a two letter code like zh/en/de/fr", s)
+	}
+	return SpokenLanguage(code), nil
+}
+
+// languageRe characters in language slugs, e.g. c++ c# objective-c visual-basic-.net ren'py
+var languageRe = regexp.MustCompile(`^[a-z0-9+#.'_-]*$`)
+
+// LanguageSlug url path segment of a language on trending page, empty means any.
+// name is case insensitive and spaces are replaced by '-', escaped slugs are accepted too.
+// e.g. C++ → c++, C# or c%23 → c%23, Objective-C → objective-c, Jupyter Notebook → jupyter-notebook.
+// use TrendingLanguages to get the slugs offered by trending page
+func LanguageSlug(lang string) (string, error) {
+	s, err := url.PathUnescape(strings.TrimSpace(lang))
+	if err != nil {
+		return "", fmt.Errorf("bad language %s", lang)
+	}
+	s = strings.Join(strings.Fields(strings.ToLower(s)), "-")
+	if !languageRe.MatchString(s) {
+		return "", fmt.Errorf("bad language %s", lang)
+	}
+	return url.PathEscape(s), nil
+}
+
+// TrendingFilter filter of trending page
+type TrendingFilter struct {
+	Lang       string         // see LanguageSlug, empty means any
+	DateRange  DateRange      // empty means DateRangeDaily
+	SpokenLang SpokenLanguage // empty means any
+}
+
+// NewTrendingFilter parse filter of trending page
+func NewTrendingFilter(lang, dateRange, spokenLang string) (TrendingFilter, error) {
+	f := TrendingFilter{Lang: lang, DateRange: DateRange(dateRange), SpokenLang: SpokenLanguage(spokenLang)}
+	_, err := f.check()
+	return f, err
+}
+
+// check normalize date range and spoken language of f, return slug of its language
+func (f *TrendingFilter) check() (string, error) {
+	slug, err := LanguageSlug(f.Lang)
+	if err != nil {
+		return "", err
+	}
+	if f.DateRange, err = ParseDateRange(string(f.DateRange)); err != nil {
+		return "", err
+	}
+	if f.SpokenLang, err = ParseSpokenLanguage(string(f.SpokenLang)); err != nil {
+		return "", err
+	}
+	return slug, nil
+}
+
+// LanguageOption a language in the filter menu of trending page
+type LanguageOption struct {
+	Name string `json:"name"` // e.g. C++
+	Slug string `json:"slug"` // e.g. c++
+}
+
+// SpokenLanguageOption a spoken language in the filter menu of trending page
+type SpokenLanguageOption struct {
+	Name string         `json:"name"` // e.g. Chinese
+	Code SpokenLanguage `json:"code"` // e.g. zh
+}
+
+// TrendingLanguages languages offered by GitHub trending
+func TrendingLanguages(ctx context.Context) ([]LanguageOption, error) {
+	return NewClient().TrendingLanguages(ctx)
+}
+
+// TrendingLanguages languages offered by trending page of the client's web url
+func (c *Client) TrendingLanguages(ctx context.Context) ([]LanguageOption, error) {
+	doc, err := c.fetchTrending(ctx, "/trending")
+	if err != nil {
+		return nil, err
+	}
+	langs, _ := parseTrendingMenus(doc)
+	return langs, nil
+}
+
+// TrendingSpokenLanguages spoken languages offered by GitHub trending
+func TrendingSpokenLanguages(ctx context.Context) ([]SpokenLanguageOption, error) {
+	return NewClient().TrendingSpokenLanguages(ctx)
+}
+
+// TrendingSpokenLanguages spoken languages offered by trending page of the client's web url
+func (c *Client) TrendingSpokenLanguages(ctx context.Context) ([]SpokenLanguageOption, error) {
+	doc, err := c.fetchTrending(ctx, "/trending")
+	if err != nil {
+		return nil, err
+	}
+	_, spoken := parseTrendingMenus(doc)
+	return spoken, nil
+}
+
+// parseTrendingMenus parse filter menu items of unfiltered trending page.
+// items linking to /trending/{lang} are languages, the ones with only spoken_language_code are spoken languages
+func parseTrendingMenus(doc *goquery.Document) ([]LanguageOption, []SpokenLanguageOption) {
+	var (
+		langs      []LanguageOption
+		spoken     []SpokenLanguageOption
+		seenLang   = make(map[string]bool)
+		seenSpoken = make(map[SpokenLanguage]bool)
+	)
+	doc.Find(`a.select-menu-item[href], a[role="menuitemradio"][href]`).Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		u, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		name := s.Find(".select-menu-item-text").First().Text()
+		if len(strings.TrimSpace(name)) == 0 {
+			name = s.Text()
+		}
+		name = strings.Join(strings.Fields(name), " ")
+
+		path := strings.TrimPrefix(u.EscapedPath(), "/trending")
+		path = strings.TrimPrefix(path, "/developers")
+		if slug := strings.Trim(path, "/"); len(slug) > 0 {
+			slug, err := LanguageSlug(slug)
+			if err == nil && !seenLang[slug] {
+				seenLang[slug] = true
+				langs = append(langs, LanguageOption{Name: name, Slug: slug})
+			}
+			return
+		}
+		code, err := ParseSpokenLanguage(u.Query().Get("spoken_language_code"))
+		if err == nil && code != SpokenLanguageAny && !seenSpoken[code] {
+			seenSpoken[code] = true
+			spoken = append(spoken, SpokenLanguageOption{Name: name, Code: code})
+		}
+	})
+	return langs, spoken
+}
@@ -0,0 +1,167 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    DateRange
+		wantErr bool
+	}{
+		{"", DateRangeDaily, false},
+		{"daily", DateRangeDaily, false},
+		{" Weekly ", DateRangeWeekly, false},
+		{"MONTHLY", DateRangeMonthly, false},
+		{"weeky", "", true},
+		{"yearly", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateRange(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDateRange(%q) = %q, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestParseSpokenLanguage(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    SpokenLanguage
+		wantErr bool
+	}{
+		{"", SpokenLanguageAny, false},
+		{"any", SpokenLanguageAny, false},
+		{"zh", "zh", false},
+		{" EN ", "en", false},
+		{"chinese", "", true},
+		{"z", "", true},
+		{"zh&since=monthly", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSpokenLanguage(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSpokenLanguage(%q) = %q, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestLanguageSlug(t *testing.T) {
+	tests := []struct {
+		lang    string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"go", "go", false},
+		{"Go", "go", false},
+		{"c++", "c++", false},
+		{"C#", "c%23", false},
+		{"c%23", "c%23", false},
+		{"F#", "f%23", false},
+		{"Objective-C", "objective-c", false},
+		{"Jupyter Notebook", "jupyter-notebook", false},
+		{"Visual Basic .NET", "visual-basic-.net", false},
+		{"go/rust", "", true},
+		{"go?since=weekly", "", true},
+		{"100%", "", true},
+	}
+	for _, tt := range tests {
+		got, err := LanguageSlug(tt.lang)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("LanguageSlug(%q) = %q, %v", tt.lang, got, err)
+		}
+	}
+}
+
+func TestTrendingInvalidParams(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer srv.Close()
+	c := NewClient(WithWebURL(srv.URL))
+
+	ctx := context.Background()
+	if _, err := c.TrendingReposContext(ctx, "go", "weeky", ""); err == nil {
+		t.Error("want error of date range")
+	}
+	if _, err := c.TrendingReposContext(ctx, "go", "weekly", "chinese"); err == nil {
+		t.Error("want error of spoken language")
+	}
+	if _, err := c.TrendingReposContext(ctx, "go/rust", "weekly", ""); err == nil {
+		t.Error("want error of language")
+	}
+	if _, err := c.TrendingDevelopers(ctx, "go", "weeky"); err == nil {
+		t.Error("want error of date range")
+	}
+	if _, err := c.TrendingReposFiltered(ctx, TrendingFilter{Lang: "go", DateRange: "weeky"}); err == nil {
+		t.Error("want error of date range")
+	}
+	if _, err := c.TrendingDevelopersFiltered(ctx, TrendingFilter{Lang: "go?x", DateRange: DateRangeWeekly}); err == nil {
+		t.Error("want error of language")
+	}
+	if requests > 0 {
+		t.Errorf("invalid params should not be requested, got %d requests", requests)
+	}
+}
+
+func TestTrendingRepoPath(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.RequestURI()
+	}))
+	defer srv.Close()
+	c := NewClient(WithWebURL(srv.URL))
+
+	c.TrendingReposContext(context.Background(), "C#", "Weekly", "ZH")
+	if want := "/trending/c%23?spoken_language_code=zh&since=weekly"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	c.TrendingReposFiltered(context.Background(), TrendingFilter{Lang: "Objective-C", DateRange: DateRangeMonthly})
+	if want := "/trending/objective-c?spoken_language_code=&since=monthly"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestTrendingMenus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trending" || len(r.URL.RawQuery) > 0 {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, "testdata/trending_menus.html")
+	}))
+	defer srv.Close()
+	c := NewClient(WithWebURL(srv.URL))
+
+	langs, err := c.TrendingLanguages(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLangs := []LanguageOption{
+		{"C++", "c++"},
+		{"C#", "c%23"},
+		{"Go", "go"},
+		{"Jupyter Notebook", "jupyter-notebook"},
+		{"Objective-C", "objective-c"},
+		{"Unknown languages", "unknown"},
+	}
+	if !reflect.DeepEqual(langs, wantLangs) {
+		t.Errorf("got %v, want %v", langs, wantLangs)
+	}
+
+	spoken, err := c.TrendingSpokenLanguages(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSpoken := []SpokenLanguageOption{{"Abkhazian", "ab"}, {"Chinese", "zh"}, {"English", "en"}}
+	if !reflect.DeepEqual(spoken, wantSpoken) {
+		t.Errorf("got %v, want %v", spoken, wantSpoken)
+	}
+}
@@ -0,0 +1,161 @@
+package ghsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrendingDevelopers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trending/developers/go" || r.URL.Query().Get("since") != "weekly" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, "testdata/trending_developers.html")
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithWebURL(srv.URL))
+	devs, err := c.TrendingDevelopers(context.Background(), "go", "weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Developer{
+		{
+			Name:            "Russ Cox",
+			Username:        "rsc",
+			Link:            srv.URL + "/rsc",
+			Avatar:          "https://avatars.githubusercontent.com/u/104030?s=96&v=4",
+			PopularRepo:     "quote",
+			PopularRepoLink: srv.URL + "/rsc/quote",
+			Desc:            "Pithy sayings",
+		},
+		{
+			Name:            "Gopher Robot",
+			Username:        "gopherbot",
+			Link:            srv.URL + "/gopherbot",
+			Avatar:          "https://avatars.githubusercontent.com/u/8566911?s=96&v=4",
+			PopularRepo:     "gobot",
+			PopularRepoLink: srv.URL + "/gopherbot/gobot",
+		},
+		{
+			Name:     "nobody",
+			Username: "nobody",
+			Link:     srv.URL + "/nobody",
+			Avatar:   "https://avatars.githubusercontent.com/u/1?s=96&v=4",
+		},
+	}
+	if len(devs) != len(want) {
+		t.Fatalf("got %d developers, want %d", len(devs), len(want))
+	}
+	for i := range want {
+		if *devs[i] != want[i] {
+			t.Errorf("got %+v, want %+v", *devs[i], want[i])
+		}
+	}
+
+	if _, err := c.TrendingDevelopers(context.Background(), "rust", "weekly"); err == nil {
+		t.Error("want error of 404")
+	}
+}
+
+func parseFixture(t *testing.T, name string) ([]*Repository, error) {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return ParseTrending(f)
+}
+
+func TestParseTrending(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []Repository
+	}{
+		{"trending_repos.html", []Repository{
+			{
+				Author: "ollama", Name: "ollama", Link: GitHubURL + "/ollama/ollama",
+				Desc: "Get up and running with Llama 3, Mistral, Gemma, and other large language models.",
+				Lang: "Go", Stars: 78311, Forks: 5735, Add: 1077, Period: "daily",
+				BuiltBy: []Contributor{
+					{"jmorganca", GitHubURL + "/jmorganca", "https://avatars.githubusercontent.com/u/251292?s=40&v=4"},
+					{"mchiang0610", GitHubURL + "/mchiang0610", "https://avatars.githubusercontent.com/u/3325447?s=40&v=4"},
+				},
+			},
+			{
+				Author: "golang", Name: "go", Link: GitHubURL + "/golang/go",
+				Desc: "The Go programming language",
+				Lang: "Go", Stars: 120558, Forks: 17312, Add: 52, Period: "daily",
+				BuiltBy: []Contributor{{"rsc", GitHubURL + "/rsc", "https://avatars.githubusercontent.com/u/104030?s=40&v=4"}},
+			},
+		}},
+		{"trending_no_lang.html", []Repository{
+			{
+				Author: "freecodecamp", Name: "awesome-list", Link: GitHubURL + "/freecodecamp/awesome-list",
+				Lang: "unknown", Stars: 2048, Forks: 96, Add: 1234, Period: "weekly",
+			},
+		}},
+		{"trending_empty.html", nil},
+	}
+	for _, tt := range tests {
+		repos, err := parseFixture(t, tt.fixture)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fixture, err)
+		}
+		if len(repos) != len(tt.want) {
+			t.Fatalf("%s: got %d repos, want %d", tt.fixture, len(repos), len(tt.want))
+		}
+		for i := range repos {
+			if !reflect.DeepEqual(*repos[i], tt.want[i]) {
+				t.Errorf("%s: got %+v, want %+v", tt.fixture, *repos[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseTrendingWarnings(t *testing.T) {
+	repos, err := parseFixture(t, "trending_broken.html")
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want ParseError, got %v", err)
+	}
+	if len(repos) != 1 || repos[0].Author != "foo" || repos[0].Name != "bar" {
+		t.Fatalf("broken fields should not drop the repository: %+v", repos)
+	}
+	want := []ParseWarning{
+		{Row: 0, Field: "stars", Value: "1.2k"},
+		{Row: 0, Field: "forks"},
+		{Row: 0, Field: "add", Value: "many stars today"},
+		{Row: 1, Field: "link"},
+	}
+	if !reflect.DeepEqual(perr.Warnings, want) {
+		t.Fatalf("got %+v", perr.Warnings)
+	}
+	if perr.Error() != `trending: parse: row 1: bad stars "1.2k"; row 1: forks missing; row 1: bad add "many stars today"; and 1 more` {
+		t.Fatal(perr.Error())
+	}
+
+	if _, err := ParseTrending(strings.NewReader("<html><body>rate limited</body></html>")); !errors.As(err, &perr) {
+		t.Fatalf("page without repositories should fail, got %v", err)
+	}
+}
+
+func TestContributorUnmarshal(t *testing.T) {
+	var r Repository
+	if err := json.Unmarshal([]byte(`{"built_by":["https://a/1.png",{"login":"rsc","link":"https://github.com/rsc"}]}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	want := []Contributor{{Avatar: "https://a/1.png"}, {Login: "rsc", Link: "https://github.com/rsc"}}
+	if !reflect.DeepEqual(r.BuiltBy, want) {
+		t.Fatalf("got %+v", r.BuiltBy)
+	}
+}
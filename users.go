@@ -0,0 +1,91 @@
+package ghsearch
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SearchUserResult one page of user search result
+type SearchUserResult = SearchResult[SearchUserResultItems]
+
+// SearchUserResultItems user or organization
+type SearchUserResultItems struct {
+	Login     string  `json:"login"`
+	ID        int     `json:"id"`
+	NodeID    string  `json:"node_id"`
+	AvatarURL string  `json:"avatar_url"`
+	URL       string  `json:"url"`
+	HTMLURL   string  `json:"html_url"`
+	ReposURL  string  `json:"repos_url"`
+	Type      string  `json:"type"` // User/Organization
+	SiteAdmin bool    `json:"site_admin"`
+	Score     float32 `json:"score"`
+}
+
+// User user or organization profile
+type User struct {
+	Login       string    `json:"login"`
+	ID          int       `json:"id"`
+	NodeID      string    `json:"node_id"`
+	AvatarURL   string    `json:"avatar_url"`
+	HTMLURL     string    `json:"html_url"`
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	Company     string    `json:"company"`
+	Blog        string    `json:"blog"`
+	Location    string    `json:"location"`
+	Email       string    `json:"email"`
+	Bio         string    `json:"bio"`
+	Twitter     string    `json:"twitter_username"`
+	PublicRepos int       `json:"public_repos"`
+	PublicGists int       `json:"public_gists"`
+	Followers   int       `json:"followers"`
+	Following   int       `json:"following"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SearchUsers search users and organizations by keywords, which may contain qualifiers like type:org followers:>1000.
+// it's the first page of SearchResult in server's order, see SearchUsersQuery and SearchUsersIter for options and more pages
+func (c *Client) SearchUsers(ctx context.Context, keywords ...string) ([]SearchUserResultItems, error) {
+	return searchKeywords[SearchUserResultItems](ctx, c, "/search/users", userSorts, keywords)
+}
+
+// SearchUsersQuery search users and organizations by structured query. items are in server's order
+// https://docs.github.com/en/rest/search/search#search-users
+func (c *Client) SearchUsersQuery(ctx context.Context, q *Query, opt *SearchOptions) (*SearchUserResult, error) {
+	return search[SearchUserResultItems](ctx, c, "/search/users", q, opt, userSorts)
+}
+
+// SearchUsersIter iterate all users matching q from opt.Page, up to MaxSearchResults
+func (c *Client) SearchUsersIter(ctx context.Context, q *Query, opt *SearchOptions) *Iterator[SearchUserResultItems] {
+	return searchIter[SearchUserResultItems](ctx, c, "/search/users", q, opt, userSorts)
+}
+
+// GetUser get user or organization profile
+// https://docs.github.com/en/rest/users/users#get-a-user
+func (c *Client) GetUser(ctx context.Context, login string) (*User, error) {
+	u := &User{}
+	if _, err := c.get(ctx, "/users/"+url.PathEscape(login), u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ListUserRepos list public repositories of user or organization, recently pushed first.
+// it uses core api quota instead of search quota
+// https://docs.github.com/en/rest/repos/repos#list-repositories-for-a-user
+func (c *Client) ListUserRepos(ctx context.Context, login string, perPage int) ([]SearchRepoResultItems, error) {
+	v := url.Values{}
+	v.Set("sort", "pushed")
+	if perPage > 0 {
+		v.Set("per_page", strconv.Itoa(perPage))
+	}
+	var repos []SearchRepoResultItems
+	if _, err := c.get(ctx, "/users/"+url.PathEscape(login)+"/repos?"+v.Encode(), &repos); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
@@ -0,0 +1,45 @@
+package ghsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchUsers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/users":
+			if q := r.URL.Query().Get("q"); q != "type:org followers:>1000 location:china" {
+				t.Errorf("q %s", q)
+			}
+			w.Write([]byte(`{"total_count":1,"items":[{"login":"golang","type":"Organization"}]}`))
+		case "/users/golang":
+			w.Write([]byte(`{"login":"golang","followers":42,"bio":"go"}`))
+		case "/users/golang/repos":
+			w.Write([]byte(`[{"name":"go","stargazers_count":100}]`))
+		default:
+			t.Errorf("path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+	r, err := c.SearchUsersQuery(ctx, NewQuery().Type("org").Followers(">1000").Location("china"), &SearchOptions{Sort: SortFollowers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Items[0].Login != "golang" {
+		t.Fatalf("%+v", r.Items)
+	}
+	u, err := c.GetUser(ctx, "golang")
+	if err != nil || u.Followers != 42 {
+		t.Fatalf("%+v %v", u, err)
+	}
+	repos, err := c.ListUserRepos(ctx, "golang", 5)
+	if err != nil || repos[0].StargazersCount != 100 {
+		t.Fatalf("%+v %v", repos, err)
+	}
+}
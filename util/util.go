@@ -2,10 +2,13 @@ package util
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/byebyebruce/ghsearch"
 )
 
 // AsyncTaskAndShowLoadingBar go a task and show loading bar
@@ -53,3 +56,15 @@ func OpenWebBrowser(url string) (err error) {
 	}
 	return err
 }
+
+// GitHubURLs resolve api and web base url.
+// priority: host(--host flag) > env GITHUB_API_URL > env GH_HOST > github.com
+func GitHubURLs(host string) (apiURL, webURL string) {
+	if len(host) > 0 {
+		return ghsearch.HostURLs(host)
+	}
+	if api := os.Getenv("GITHUB_API_URL"); len(api) > 0 {
+		return strings.TrimRight(api, "/"), ghsearch.WebURLFromAPI(api)
+	}
+	return ghsearch.HostURLs(os.Getenv("GH_HOST"))
+}